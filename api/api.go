@@ -31,6 +31,7 @@ func Register(router *gin.Engine, db *gorm.DB, trans ut.Translator, authHost str
 	v1.Use(middleware.TransactionMiddleware(db))
 	v1.Use(middleware.TranslationMiddleware(trans))
 	v1.Use(middleware.ErrorMiddleware)
+	v1.Use(ConflictErrorMiddleware)
 
 	// Theaters
 	v1.GET("/theaters", TheatersList)
@@ -48,6 +49,11 @@ func Register(router *gin.Engine, db *gorm.DB, trans ut.Translator, authHost str
 	theatersAdminWithID.Use(middleware.RequireAdmin())
 	theatersAdminWithID.PUT("", TheatersUpdate)
 	theatersAdminWithID.DELETE("", TheatersDelete)
+	theatersAdminWithID.POST("/schedule/populate", SchedulePopulate)
+	theatersAdminWithID.POST("/schedule/copy", ScheduleCopy)
+	theatersAdminWithID.GET("/schedule/runs", ScheduleRunsList)
+	theatersAdminWithID.GET("/schedule/runs/:runID", ScheduleRunsShow)
+	theatersAdminWithID.GET("/schedule/demand", ScheduleDemand)
 
 	// Rooms
 	theaters.GET("/rooms", RoomsList)
@@ -65,6 +71,7 @@ func Register(router *gin.Engine, db *gorm.DB, trans ut.Translator, authHost str
 	movies := v1.Group("/movies/:movieID")
 	movies.Use(MovieContextMiddleware)
 	movies.GET("", MoviesShow)
+	movies.GET("/showtimes", MovieShowtimes)
 
 	moviesAdmin := v1.Group("/movies")
 	moviesAdmin.Use(middleware.UserMiddleware(authHost))
@@ -78,8 +85,120 @@ func Register(router *gin.Engine, db *gorm.DB, trans ut.Translator, authHost str
 	moviesAdminWithID.DELETE("", MoviesDelete)
 
 	// TimeSlots
+	theaters.GET("/timeslots", TheaterTimeSlotsList)
+	theaters.GET("/now", TheaterNow)
+	theaters.GET("/now/compact", TheaterNowCompact)
 	theaters.GET("/rooms/:roomID/timeslots", TimeSlotsList)
 	theaters.GET("/rooms/:roomID/timeslots/:timeSlotID", TimeSlotsShow)
+
+	// Schedule templates
+	rooms := theaters.Group("/rooms/:roomID")
+	rooms.Use(RoomContextMiddleware)
+	rooms.GET("/templates", ScheduleTemplatesList)
+	rooms.GET("/templates/:templateID", ScheduleTemplatesShow)
+
+	templatesAdmin := rooms.Group("/templates")
+	templatesAdmin.Use(middleware.UserMiddleware(authHost))
+	templatesAdmin.Use(middleware.RequireAdmin())
+	templatesAdmin.POST("", ScheduleTemplatesCreate)
+	templatesAdmin.PUT("/:templateID", ScheduleTemplatesUpdate)
+	templatesAdmin.DELETE("/:templateID", ScheduleTemplatesDelete)
+
+	// TimeSlot locks
+	timeSlotsAdmin := rooms.Group("/timeslots/:timeSlotID")
+	timeSlotsAdmin.Use(middleware.UserMiddleware(authHost))
+	timeSlotsAdmin.Use(middleware.RequireAdmin())
+	timeSlotsAdmin.POST("/lock", TimeSlotsLock)
+	timeSlotsAdmin.POST("/unlock", TimeSlotsUnlock)
+	timeSlotsAdmin.POST("/cancel", TimeSlotsCancel)
+
+	roomTimeSlotsAdmin := rooms.Group("/timeslots")
+	roomTimeSlotsAdmin.Use(middleware.UserMiddleware(authHost))
+	roomTimeSlotsAdmin.Use(middleware.RequireAdmin())
+	roomTimeSlotsAdmin.POST("/shift", TimeSlotsShift)
+
+	// Occupancy
+	occupancyAdmin := rooms.Group("/timeslots/:timeSlotID/occupancy")
+	occupancyAdmin.Use(middleware.UserMiddleware(authHost))
+	occupancyAdmin.Use(middleware.RequireAdmin())
+	occupancyAdmin.PUT("", TimeSlotOccupancyUpdate)
+
+	// Forecasts
+	forecastsAdmin := rooms.Group("/timeslots/:timeSlotID/forecast")
+	forecastsAdmin.Use(middleware.UserMiddleware(authHost))
+	forecastsAdmin.Use(middleware.RequireAdmin())
+	forecastsAdmin.GET("", TimeSlotForecastShow)
+
+	theaterForecastsAdmin := theaters.Group("/forecast")
+	theaterForecastsAdmin.Use(middleware.UserMiddleware(authHost))
+	theaterForecastsAdmin.Use(middleware.RequireAdmin())
+	theaterForecastsAdmin.GET("", TheaterForecastShow)
+
+	// Seats
+	rooms.GET("/seats", RoomSeatsShow)
+
+	seatsAdmin := rooms.Group("/seats")
+	seatsAdmin.Use(middleware.UserMiddleware(authHost))
+	seatsAdmin.Use(middleware.RequireAdmin())
+	seatsAdmin.PUT("", RoomSeatsUpdate)
+	seatsAdmin.PUT("/plan", RoomSeatPlanImport)
+
+	// Seat holds
+	rooms.GET("/timeslots/:timeSlotID/seats", TimeSlotSeatsAvailability)
+
+	seatHolds := rooms.Group("/timeslots/:timeSlotID/holds")
+	seatHolds.Use(middleware.UserMiddleware(authHost))
+	seatHolds.POST("", SeatHoldsCreate)
+	seatHolds.DELETE("/:holdID", SeatHoldsRelease)
+
+	seatHoldsAdmin := rooms.Group("/timeslots/:timeSlotID/holds/:holdID/confirm")
+	seatHoldsAdmin.Use(middleware.UserMiddleware(authHost))
+	seatHoldsAdmin.Use(middleware.RequireAdmin())
+	seatHoldsAdmin.POST("", SeatHoldsConfirm)
+
+	seatReservationsAdmin := rooms.Group("/timeslots/:timeSlotID/reservations")
+	seatReservationsAdmin.Use(middleware.UserMiddleware(authHost))
+	seatReservationsAdmin.Use(middleware.RequireAdmin())
+	seatReservationsAdmin.DELETE("/:holdID", SeatReservationsCancel)
+
+	// Prices
+	theaters.GET("/prices", PriceListsShow)
+
+	pricesAdmin := theaters.Group("/prices")
+	pricesAdmin.Use(middleware.UserMiddleware(authHost))
+	pricesAdmin.Use(middleware.RequireAdmin())
+	pricesAdmin.PUT("", PriceListsUpdate)
+	pricesAdmin.DELETE("", PriceListsDelete)
+
+	timeSlotPricesAdmin := rooms.Group("/timeslots/:timeSlotID/prices")
+	timeSlotPricesAdmin.Use(middleware.UserMiddleware(authHost))
+	timeSlotPricesAdmin.Use(middleware.RequireAdmin())
+	timeSlotPricesAdmin.PUT("", TimeSlotPricesUpdate)
+	timeSlotPricesAdmin.DELETE("", TimeSlotPricesDelete)
+
+	// Promotions
+	v1.GET("/promotions", PromotionsList)
+	v1.GET("/promotions/:promotionID", PromotionsShow)
+	rooms.GET("/timeslots/:timeSlotID/prices", TimeSlotPricesEvaluate)
+
+	promotionsAdmin := v1.Group("/promotions")
+	promotionsAdmin.Use(middleware.UserMiddleware(authHost))
+	promotionsAdmin.Use(middleware.RequireAdmin())
+	promotionsAdmin.POST("", PromotionsCreate)
+	promotionsAdmin.PUT("/:promotionID", PromotionsUpdate)
+	promotionsAdmin.DELETE("/:promotionID", PromotionsDelete)
+
+	// Calendars
+	theaters.GET("/calendar.ics", TheaterCalendar)
+	rooms.GET("/calendar.ics", RoomCalendar)
+	movies.GET("/calendar.ics", MovieCalendar)
+
+	// Exports
+	theaters.GET("/export.csv", TheaterExportCSV)
+	theaters.GET("/export.html", TheaterExportHTML)
+
+	// Structured data
+	theaters.GET("/screenings.jsonld", TheaterScreenings)
 }
 
 func healthcheck(c *gin.Context) {
@@ -1,14 +1,20 @@
 package api
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
 	"testing"
 
 	"github.com/PRPO-skupina-02/common/clients/auth/models"
 	"github.com/PRPO-skupina-02/common/middleware"
 	"github.com/PRPO-skupina-02/common/validation"
+	"github.com/PRPO-skupina-02/common/xtesting"
 	"github.com/gin-gonic/gin"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
@@ -28,12 +34,36 @@ func MockAdminMiddleware() gin.HandlerFunc {
 	}
 }
 
+func MockCustomerMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := &models.APIUserResponse{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002").String(),
+			Email:     "customer@example.com",
+			FirstName: "Customer",
+			LastName:  "User",
+			Role:      models.ModelsUserRoleCustomer,
+			Active:    true,
+		}
+		middleware.SetContextUser(c, user)
+		c.Next()
+	}
+}
+
 func TestingRouter(t *testing.T, db *gorm.DB) *gin.Engine {
+	return testingRouterWithUser(t, db, MockAdminMiddleware())
+}
+
+// CustomerTestingRouter serves the routes to a customer instead of an admin
+func CustomerTestingRouter(t *testing.T, db *gorm.DB) *gin.Engine {
+	return testingRouterWithUser(t, db, MockCustomerMiddleware())
+}
+
+func testingRouterWithUser(t *testing.T, db *gorm.DB, userMiddleware gin.HandlerFunc) *gin.Engine {
 	router := gin.Default()
 	trans, err := validation.RegisterValidation()
 	require.NoError(t, err)
 
-	router.Use(MockAdminMiddleware())
+	router.Use(userMiddleware)
 
 	registerTestRoutes(router, db, trans)
 
@@ -49,6 +79,7 @@ func registerTestRoutes(router *gin.Engine, db *gorm.DB, trans ut.Translator) {
 	v1.Use(middleware.TransactionMiddleware(db))
 	v1.Use(middleware.TranslationMiddleware(trans))
 	v1.Use(middleware.ErrorMiddleware)
+	v1.Use(ConflictErrorMiddleware)
 
 	// Public routes
 	v1.GET("/theaters", TheatersList)
@@ -61,6 +92,11 @@ func registerTestRoutes(router *gin.Engine, db *gorm.DB, trans ut.Translator) {
 	v1.POST("/theaters", TheatersCreate)
 	theaters.PUT("", TheatersUpdate)
 	theaters.DELETE("", TheatersDelete)
+	theaters.POST("/schedule/populate", SchedulePopulate)
+	theaters.POST("/schedule/copy", ScheduleCopy)
+	theaters.GET("/schedule/runs", ScheduleRunsList)
+	theaters.GET("/schedule/runs/:runID", ScheduleRunsShow)
+	theaters.GET("/schedule/demand", ScheduleDemand)
 
 	// Rooms
 	theaters.GET("/rooms", RoomsList)
@@ -73,11 +109,85 @@ func registerTestRoutes(router *gin.Engine, db *gorm.DB, trans ut.Translator) {
 	movies := v1.Group("/movies/:movieID")
 	movies.Use(MovieContextMiddleware)
 	movies.GET("", MoviesShow)
+	movies.GET("/showtimes", MovieShowtimes)
 	v1.POST("/movies", MoviesCreate)
 	movies.PUT("", MoviesUpdate)
 	movies.DELETE("", MoviesDelete)
 
 	// TimeSlots
+	theaters.GET("/timeslots", TheaterTimeSlotsList)
+	theaters.GET("/now", TheaterNow)
+	theaters.GET("/now/compact", TheaterNowCompact)
 	theaters.GET("/rooms/:roomID/timeslots", TimeSlotsList)
 	theaters.GET("/rooms/:roomID/timeslots/:timeSlotID", TimeSlotsShow)
+
+	// Schedule templates
+	rooms := theaters.Group("/rooms/:roomID")
+	rooms.Use(RoomContextMiddleware)
+	rooms.GET("/templates", ScheduleTemplatesList)
+	rooms.GET("/templates/:templateID", ScheduleTemplatesShow)
+	rooms.POST("/templates", ScheduleTemplatesCreate)
+	rooms.PUT("/templates/:templateID", ScheduleTemplatesUpdate)
+	rooms.DELETE("/templates/:templateID", ScheduleTemplatesDelete)
+	rooms.POST("/timeslots/:timeSlotID/lock", TimeSlotsLock)
+	rooms.POST("/timeslots/:timeSlotID/unlock", TimeSlotsUnlock)
+	rooms.POST("/timeslots/:timeSlotID/cancel", TimeSlotsCancel)
+	rooms.POST("/timeslots/shift", TimeSlotsShift)
+	rooms.GET("/seats", RoomSeatsShow)
+	rooms.PUT("/seats", RoomSeatsUpdate)
+	rooms.PUT("/seats/plan", RoomSeatPlanImport)
+	rooms.GET("/timeslots/:timeSlotID/seats", TimeSlotSeatsAvailability)
+	rooms.POST("/timeslots/:timeSlotID/holds", SeatHoldsCreate)
+	rooms.POST("/timeslots/:timeSlotID/holds/:holdID/confirm", SeatHoldsConfirm)
+	rooms.DELETE("/timeslots/:timeSlotID/holds/:holdID", SeatHoldsRelease)
+	rooms.DELETE("/timeslots/:timeSlotID/reservations/:holdID", SeatReservationsCancel)
+	theaters.GET("/prices", PriceListsShow)
+	theaters.PUT("/prices", PriceListsUpdate)
+	theaters.DELETE("/prices", PriceListsDelete)
+	rooms.PUT("/timeslots/:timeSlotID/prices", TimeSlotPricesUpdate)
+	rooms.DELETE("/timeslots/:timeSlotID/prices", TimeSlotPricesDelete)
+	v1.GET("/promotions", PromotionsList)
+	v1.GET("/promotions/:promotionID", PromotionsShow)
+	v1.POST("/promotions", PromotionsCreate)
+	v1.PUT("/promotions/:promotionID", PromotionsUpdate)
+	v1.DELETE("/promotions/:promotionID", PromotionsDelete)
+	rooms.GET("/timeslots/:timeSlotID/prices", TimeSlotPricesEvaluate)
+	rooms.PUT("/timeslots/:timeSlotID/occupancy", TimeSlotOccupancyUpdate)
+
+	// Forecasts
+	rooms.GET("/timeslots/:timeSlotID/forecast", TimeSlotForecastShow)
+	theaters.GET("/forecast", TheaterForecastShow)
+
+	// Calendars
+	theaters.GET("/calendar.ics", TheaterCalendar)
+	rooms.GET("/calendar.ics", RoomCalendar)
+	movies.GET("/calendar.ics", MovieCalendar)
+
+	// Exports
+	theaters.GET("/export.csv", TheaterExportCSV)
+	theaters.GET("/export.html", TheaterExportHTML)
+
+	// Structured data
+	theaters.GET("/screenings.jsonld", TheaterScreenings)
+}
+
+// assertGoldenText compares a non JSON response to its golden file, errors are
+// still rendered as JSON. Matches of the dynamic expressions are replaced
+// before comparing, like dynamic values of JSON responses.
+func assertGoldenText(t *testing.T, w *httptest.ResponseRecorder, contentType string, dynamic ...*regexp.Regexp) {
+	if w.Code != http.StatusOK {
+		xtesting.AssertGoldenJSON(t, w)
+		return
+	}
+
+	assert.Equal(t, contentType, w.Header().Get("content-type"))
+
+	body := w.Body.String()
+	for _, expression := range dynamic {
+		body = expression.ReplaceAllString(body, "${1}-- Dynamic value --")
+	}
+
+	fileNamePath := fmt.Sprintf("testdata/%s.golden", t.Name())
+	xtesting.UpdateGoldenIfFlagSet(t, []byte(body), fileNamePath)
+	assert.Equal(t, string(xtesting.ReadGoldenFile(t, fileNamePath)), body)
 }
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/PRPO-skupina-02/common/request"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// Feeds without a from or date filter start this many days in the past
+	calendarPastDays = 30
+
+	calendarProductID  = "-//PRPO skupina 02//Spored//EN"
+	calendarUIDDomain  = "spored.prpo"
+	calendarTimeLayout = "20060102T150405Z"
+	// Content lines longer than this many octets are folded, continuation
+	// lines count the leading space as well
+	calendarLineLength = 75
+
+	// Cancelling a time slot is a revision of its event, so that clients
+	// replace the confirmed event they already have
+	calendarConfirmedSequence = 0
+	calendarCancelledSequence = 1
+)
+
+var calendarTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// calendarWriter builds an RFC 5545 iCalendar object, all times are written
+// in UTC so no VTIMEZONE components are needed
+type calendarWriter struct {
+	builder strings.Builder
+}
+
+func (w *calendarWriter) line(name, value string) {
+	line := name + ":" + value
+
+	length := calendarLineLength
+	for len(line) > length {
+		cut := length
+		for !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.builder.WriteString(line[:cut])
+		w.builder.WriteString("\r\n ")
+		line = line[cut:]
+		length = calendarLineLength - 1
+	}
+
+	w.builder.WriteString(line)
+	w.builder.WriteString("\r\n")
+}
+
+func (w *calendarWriter) text(name, value string) {
+	w.line(name, calendarTextEscaper.Replace(value))
+}
+
+func (w *calendarWriter) time(name string, value time.Time) {
+	w.line(name, value.UTC().Format(calendarTimeLayout))
+}
+
+func (w *calendarWriter) event(timeSlot models.TimeSlot, now time.Time) {
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", fmt.Sprintf("%s@%s", timeSlot.ID, calendarUIDDomain))
+	w.time("DTSTAMP", now)
+	w.time("LAST-MODIFIED", timeSlot.UpdatedAt)
+	w.time("DTSTART", timeSlot.StartTime)
+	w.time("DTEND", timeSlot.EndTime)
+	w.text("SUMMARY", timeSlot.Movie.Title)
+	w.text("LOCATION", fmt.Sprintf("%s, %s", timeSlot.Room.Theater.Name, timeSlot.Room.Name))
+
+	description := timeSlot.Movie.Description
+	if timeSlot.Status == models.CancelledTimeSlot {
+		w.line("STATUS", "CANCELLED")
+		w.line("SEQUENCE", fmt.Sprint(calendarCancelledSequence))
+		if timeSlot.CancellationReason != nil {
+			description = fmt.Sprintf("Cancelled: %s\n\n%s", *timeSlot.CancellationReason, description)
+		}
+	} else {
+		w.line("STATUS", "CONFIRMED")
+		w.line("SEQUENCE", fmt.Sprint(calendarConfirmedSequence))
+	}
+	w.text("DESCRIPTION", description)
+
+	w.line("END", "VEVENT")
+}
+
+// newCalendar writes the time slots as events stamped with now, the time the
+// feed was generated
+func newCalendar(name string, timeSlots []models.TimeSlot, now time.Time) []byte {
+	w := calendarWriter{}
+
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", calendarProductID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	w.text("X-WR-CALNAME", name)
+	w.line("X-WR-TIMEZONE", "UTC")
+
+	for _, timeSlot := range timeSlots {
+		w.event(timeSlot, now)
+	}
+
+	w.line("END", "VCALENDAR")
+
+	return []byte(w.builder.String())
+}
+
+func renderCalendar(c *gin.Context, name string, filter request.Filter) {
+	tx := middleware.GetContextTransaction(c)
+
+	filters, err := getTimeSlotScheduleFilters(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	filters = append(filters, filter)
+
+	now := time.Now()
+	if c.Query("from") == "" && c.Query("date") == "" {
+		from := now.AddDate(0, 0, -calendarPastDays)
+		filters = append(filters, models.TimeRangeFilter{Column: "time_slots.start_time", From: &from})
+	}
+
+	timeSlots, err := models.GetCalendarTimeSlots(tx, request.NewFilterOptions(filters...))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", "inline; filename=\"calendar.ics\"")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", newCalendar(name, timeSlots, now))
+}
+
+// TheaterCalendar
+//
+//	@Id				TheaterCalendar
+//	@Summary		Theater calendar feed
+//	@Description	iCalendar feed of the theater programme, cancelled time slots are included with a cancelled status. Without a from or date filter the feed starts 30 days in the past
+//	@Tags			calendars
+//	@Produce		text/calendar
+//	@Param			theaterID		path		string	true	"Theater ID"					Format(uuid)
+//	@Param			date			query		string	false	"Filter by date (YYYY-MM-DD)"	Format(date)
+//	@Param			from			query		string	false	"Filter by first date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			to				query		string	false	"Filter by last date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			start_after		query		string	false	"Filter by start time of day (HH:MM, UTC), inclusive"
+//	@Param			start_before	query		string	false	"Filter by start time of day (HH:MM, UTC), exclusive"
+//	@Param			weekday			query		string	false	"Comma separated weekdays (monday, ..., sunday)"
+//	@Success		200				{string}	string	"iCalendar feed"
+//	@Failure		400				{object}	middleware.HttpError
+//	@Failure		404				{object}	middleware.HttpError
+//	@Failure		500				{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/calendar.ics [get]
+func TheaterCalendar(c *gin.Context) {
+	theater := GetContextTheater(c)
+	renderCalendar(c, theater.Name, models.TimeSlotTheaterFilter{TheaterID: theater.ID})
+}
+
+// RoomCalendar
+//
+//	@Id				RoomCalendar
+//	@Summary		Room calendar feed
+//	@Description	iCalendar feed of the room programme, cancelled time slots are included with a cancelled status. Without a from or date filter the feed starts 30 days in the past
+//	@Tags			calendars
+//	@Produce		text/calendar
+//	@Param			theaterID		path		string	true	"Theater ID"					Format(uuid)
+//	@Param			roomID			path		string	true	"Room ID"						Format(uuid)
+//	@Param			date			query		string	false	"Filter by date (YYYY-MM-DD)"	Format(date)
+//	@Param			from			query		string	false	"Filter by first date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			to				query		string	false	"Filter by last date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			start_after		query		string	false	"Filter by start time of day (HH:MM, UTC), inclusive"
+//	@Param			start_before	query		string	false	"Filter by start time of day (HH:MM, UTC), exclusive"
+//	@Param			weekday			query		string	false	"Comma separated weekdays (monday, ..., sunday)"
+//	@Success		200				{string}	string	"iCalendar feed"
+//	@Failure		400				{object}	middleware.HttpError
+//	@Failure		404				{object}	middleware.HttpError
+//	@Failure		500				{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/calendar.ics [get]
+func RoomCalendar(c *gin.Context) {
+	theater := GetContextTheater(c)
+	room := GetContextRoom(c)
+	renderCalendar(c, fmt.Sprintf("%s, %s", theater.Name, room.Name), models.TimeSlotRoomFilter{RoomID: room.ID})
+}
+
+// MovieCalendar
+//
+//	@Id				MovieCalendar
+//	@Summary		Movie calendar feed
+//	@Description	iCalendar feed of the movie showtimes across all theaters, cancelled time slots are included with a cancelled status. Without a from or date filter the feed starts 30 days in the past
+//	@Tags			calendars
+//	@Produce		text/calendar
+//	@Param			movieID			path		string	true	"Movie ID"						Format(uuid)
+//	@Param			date			query		string	false	"Filter by date (YYYY-MM-DD)"	Format(date)
+//	@Param			from			query		string	false	"Filter by first date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			to				query		string	false	"Filter by last date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			start_after		query		string	false	"Filter by start time of day (HH:MM, UTC), inclusive"
+//	@Param			start_before	query		string	false	"Filter by start time of day (HH:MM, UTC), exclusive"
+//	@Param			weekday			query		string	false	"Comma separated weekdays (monday, ..., sunday)"
+//	@Success		200				{string}	string	"iCalendar feed"
+//	@Failure		400				{object}	middleware.HttpError
+//	@Failure		404				{object}	middleware.HttpError
+//	@Failure		500				{object}	middleware.HttpError
+//	@Router			/movies/{movieID}/calendar.ics [get]
+func MovieCalendar(c *gin.Context) {
+	movie := GetContextMovie(c)
+	renderCalendar(c, movie.Title, models.TimeSlotMovieFilter{MovieID: movie.ID})
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/PRPO-skupina-02/common/database"
+	"github.com/PRPO-skupina-02/common/xtesting"
+	"github.com/PRPO-skupina-02/spored/db"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// Events are stamped with the time the feed was generated
+var calendarStampExpression = regexp.MustCompile(`(DTSTAMP:)[0-9]{8}T[0-9]{6}Z`)
+
+func TestTheaterCalendar(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name       string
+		status     int
+		theaterID  string
+		params     string
+		cancelSlot string
+	}{
+		{
+			name:      "ok",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?date=2026-01-05",
+		},
+		{
+			name:       "ok-cancelled",
+			status:     http.StatusOK,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:     "?date=2026-01-05",
+			cancelSlot: "6d99f9b1-4d38-4f35-bb5c-13730c3f2e75",
+		},
+		{
+			name:      "ok-default-window",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+		},
+		{
+			name:      "ok-no-rooms",
+			status:    http.StatusOK,
+			theaterID: "ea0b7f96-ddc9-11f0-9635-23efd36396bd",
+		},
+		{
+			name:      "invalid-date-range",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?from=soon",
+		},
+		{
+			name:      "invalid-theater-id",
+			status:    http.StatusNotFound,
+			theaterID: "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:      "nil-theater-id",
+			status:    http.StatusBadRequest,
+			theaterID: "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			name:      "malformed-theater-id",
+			status:    http.StatusBadRequest,
+			theaterID: "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			if testCase.cancelSlot != "" {
+				err = db.Model(&models.TimeSlot{}).Where("id = ?", testCase.cancelSlot).UpdateColumns(map[string]any{
+					"status":              models.CancelledTimeSlot,
+					"cancellation_reason": "Projector is broken",
+				}).Error
+				assert.NoError(t, err)
+			}
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/calendar.ics%s", testCase.theaterID, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			assertGoldenText(t, w, "text/calendar; charset=utf-8", calendarStampExpression)
+		})
+	}
+}
+
+func TestRoomCalendar(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name   string
+		status int
+		roomID string
+		params string
+	}{
+		{
+			name:   "ok",
+			status: http.StatusOK,
+			roomID: "925c2358-df46-11f0-a38e-abe580bde3d1",
+			params: "?date=2025-12-30",
+		},
+		{
+			name:   "invalid-room-id",
+			status: http.StatusNotFound,
+			roomID: "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:   "nil-room-id",
+			status: http.StatusBadRequest,
+			roomID: "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			name:   "malformed-room-id",
+			status: http.StatusBadRequest,
+			roomID: "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/bae209f6-d059-11f0-b2a4-cbf992c2eb6d/rooms/%s/calendar.ics%s", testCase.roomID, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			assertGoldenText(t, w, "text/calendar; charset=utf-8", calendarStampExpression)
+		})
+	}
+}
+
+func TestMovieCalendar(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name   string
+		status int
+		id     string
+		params string
+	}{
+		{
+			name:   "ok",
+			status: http.StatusOK,
+			id:     "27e36818-e240-11f0-bb29-538173c01e43",
+			params: "?from=2025-12-30&to=2025-12-31",
+		},
+		{
+			name:   "invalid-id",
+			status: http.StatusNotFound,
+			id:     "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:   "nil-id",
+			status: http.StatusBadRequest,
+			id:     "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			name:   "malformed-id",
+			status: http.StatusBadRequest,
+			id:     "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/movies/%s/calendar.ics%s", testCase.id, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			assertGoldenText(t, w, "text/calendar; charset=utf-8", calendarStampExpression)
+		})
+	}
+}
@@ -197,7 +197,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update movie",
+                "description": "Update movie. Changing the length recalculates its future time slots and pushes back the time slots following them, time slots that can not be pushed back before closing time or would overlap a locked time slot are cancelled. Deactivating the movie with cancel_future_timeslots cancels its unlocked future time slots and fills the gaps with other movies, time slots with reservations are only cancelled with force, otherwise the update fails with 409. The affected time slots are listed in schedule_changes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -218,6 +218,12 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "boolean",
+                        "description": "Cancel future time slots with reservations",
+                        "name": "force",
+                        "in": "query"
+                    },
                     {
                         "description": "request body",
                         "name": "request",
@@ -232,7 +238,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.MovieResponse"
+                            "$ref": "#/definitions/api.MovieUpdateResponse"
                         }
                     },
                     "400": {
@@ -247,6 +253,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.HttpError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -303,9 +315,95 @@ const docTemplate = `{
                 }
             }
         },
-        "/theaters": {
+        "/movies/{movieID}/calendar.ics": {
             "get": {
-                "description": "List theaters",
+                "description": "iCalendar feed of the movie showtimes across all theaters, cancelled time slots are included with a cancelled status. Without a from or date filter the feed starts 30 days in the past",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendars"
+                ],
+                "summary": "Movie calendar feed",
+                "operationId": "MovieCalendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Movie ID",
+                        "name": "movieID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Filter by date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by first date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by last date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), inclusive",
+                        "name": "start_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), exclusive",
+                        "name": "start_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated weekdays (monday, ..., sunday)",
+                        "name": "weekday",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/{movieID}/showtimes": {
+            "get": {
+                "description": "List upcoming showtimes of the movie across all theaters, grouped by theater and day",
                 "consumes": [
                     "application/json"
                 ],
@@ -313,10 +411,106 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "theaters"
+                    "movies"
                 ],
-                "summary": "List theaters",
-                "operationId": "TheatersList",
+                "summary": "List movie showtimes",
+                "operationId": "MovieShowtimes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Movie ID",
+                        "name": "movieID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Filter by date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by first date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by last date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), inclusive",
+                        "name": "start_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), exclusive",
+                        "name": "start_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated weekdays (monday, ..., sunday)",
+                        "name": "weekday",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Filter by theater",
+                        "name": "theater_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.MovieShowtimesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions": {
+            "get": {
+                "description": "List promotions ordered by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "List promotions",
+                "operationId": "PromotionsList",
                 "parameters": [
                     {
                         "type": "integer",
@@ -353,7 +547,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/api.TheaterResponse"
+                                                "$ref": "#/definitions/api.PromotionResponse"
                                             }
                                         }
                                     }
@@ -382,7 +576,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create theater",
+                "description": "Create a promotion that discounts tickets of time slots meeting all of its conditions, conditions that are left empty match every time slot",
                 "consumes": [
                     "application/json"
                 ],
@@ -390,10 +584,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "theaters"
+                    "promotions"
                 ],
-                "summary": "Create theater",
-                "operationId": "TheatersCreate",
+                "summary": "Create promotion",
+                "operationId": "PromotionsCreate",
                 "parameters": [
                     {
                         "description": "request body",
@@ -401,15 +595,15 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.TheaterRequest"
+                            "$ref": "#/definitions/api.PromotionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.TheaterResponse"
+                            "$ref": "#/definitions/api.PromotionResponse"
                         }
                     },
                     "400": {
@@ -433,9 +627,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/theaters/{theaterID}": {
+        "/promotions/{promotionID}": {
             "get": {
-                "description": "Show theater",
+                "description": "Show promotion",
                 "consumes": [
                     "application/json"
                 ],
@@ -443,16 +637,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "theaters"
+                    "promotions"
                 ],
-                "summary": "Show theater",
-                "operationId": "TheatersShow",
+                "summary": "Show promotion",
+                "operationId": "PromotionsShow",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Theater ID",
-                        "name": "theaterID",
+                        "description": "Promotion ID",
+                        "name": "promotionID",
                         "in": "path",
                         "required": true
                     }
@@ -461,7 +655,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TheaterResponse"
+                            "$ref": "#/definitions/api.PromotionResponse"
                         }
                     },
                     "400": {
@@ -485,7 +679,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update theater",
+                "description": "Update promotion",
                 "consumes": [
                     "application/json"
                 ],
@@ -493,16 +687,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "theaters"
+                    "promotions"
                 ],
-                "summary": "Update theater",
-                "operationId": "TheatersUpdate",
+                "summary": "Update promotion",
+                "operationId": "PromotionsUpdate",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Theater ID",
-                        "name": "theaterID",
+                        "description": "Promotion ID",
+                        "name": "promotionID",
                         "in": "path",
                         "required": true
                     },
@@ -512,7 +706,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.TheaterRequest"
+                            "$ref": "#/definitions/api.PromotionRequest"
                         }
                     }
                 ],
@@ -520,7 +714,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TheaterResponse"
+                            "$ref": "#/definitions/api.PromotionResponse"
                         }
                     },
                     "400": {
@@ -544,7 +738,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete theater",
+                "description": "Delete promotion",
                 "consumes": [
                     "application/json"
                 ],
@@ -552,16 +746,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "theaters"
+                    "promotions"
                 ],
-                "summary": "Delete theater",
-                "operationId": "TheatersDelete",
+                "summary": "Delete promotion",
+                "operationId": "PromotionsDelete",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Theater ID",
-                        "name": "theaterID",
+                        "description": "Promotion ID",
+                        "name": "promotionID",
                         "in": "path",
                         "required": true
                     }
@@ -591,9 +785,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/theaters/{theaterID}/rooms": {
+        "/theaters": {
             "get": {
-                "description": "List rooms",
+                "description": "List theaters",
                 "consumes": [
                     "application/json"
                 ],
@@ -601,19 +795,11 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "rooms"
+                    "theaters"
                 ],
-                "summary": "List rooms",
-                "operationId": "RoomsList",
+                "summary": "List theaters",
+                "operationId": "TheatersList",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Theater ID",
-                        "name": "theaterID",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "integer",
                         "default": 10,
@@ -633,6 +819,12 @@ const docTemplate = `{
                         "description": "Sort results",
                         "name": "sort",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated related resources to expand (rooms)",
+                        "name": "expand",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -649,7 +841,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/api.RoomResponse"
+                                                "$ref": "#/definitions/api.TheaterResponse"
                                             }
                                         }
                                     }
@@ -678,7 +870,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create room",
+                "description": "Create theater",
                 "consumes": [
                     "application/json"
                 ],
@@ -686,26 +878,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "rooms"
+                    "theaters"
                 ],
-                "summary": "Create room",
-                "operationId": "RoomsCreate",
+                "summary": "Create theater",
+                "operationId": "TheatersCreate",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Theater ID",
-                        "name": "theaterID",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "request body",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.RoomRequest"
+                            "$ref": "#/definitions/api.TheaterRequest"
                         }
                     }
                 ],
@@ -713,7 +897,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.RoomResponse"
+                            "$ref": "#/definitions/api.TheaterResponse"
                         }
                     },
                     "400": {
@@ -737,9 +921,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/theaters/{theaterID}/rooms/{roomID}": {
+        "/theaters/{theaterID}": {
             "get": {
-                "description": "Show room",
+                "description": "Show theater",
                 "consumes": [
                     "application/json"
                 ],
@@ -747,10 +931,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "rooms"
+                    "theaters"
                 ],
-                "summary": "Show room",
-                "operationId": "RoomsShow",
+                "summary": "Show theater",
+                "operationId": "TheatersShow",
                 "parameters": [
                     {
                         "type": "string",
@@ -762,18 +946,16 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "Room ID",
-                        "name": "roomID",
-                        "in": "path",
-                        "required": true
+                        "description": "Comma separated related resources to expand (rooms)",
+                        "name": "expand",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.RoomResponse"
+                            "$ref": "#/definitions/api.TheaterResponse"
                         }
                     },
                     "400": {
@@ -797,7 +979,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update room",
+                "description": "Update theater",
                 "consumes": [
                     "application/json"
                 ],
@@ -805,10 +987,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "rooms"
+                    "theaters"
                 ],
-                "summary": "Update room",
-                "operationId": "RoomsUpdate",
+                "summary": "Update theater",
+                "operationId": "TheatersUpdate",
                 "parameters": [
                     {
                         "type": "string",
@@ -818,21 +1000,13 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Room ID",
-                        "name": "roomID",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "request body",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.RoomRequest"
+                            "$ref": "#/definitions/api.TheaterRequest"
                         }
                     }
                 ],
@@ -840,7 +1014,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.RoomResponse"
+                            "$ref": "#/definitions/api.TheaterResponse"
                         }
                     },
                     "400": {
@@ -864,7 +1038,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete room",
+                "description": "Delete theater together with its rooms and cancel their future time slots. Theaters with reservations for future time slots are not deleted and the reserved time slots are listed in a conflict, unless forced",
                 "consumes": [
                     "application/json"
                 ],
@@ -872,10 +1046,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "rooms"
+                    "theaters"
                 ],
-                "summary": "Delete room",
-                "operationId": "RoomsDelete",
+                "summary": "Delete theater",
+                "operationId": "TheatersDelete",
                 "parameters": [
                     {
                         "type": "string",
@@ -886,12 +1060,10 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Room ID",
-                        "name": "roomID",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Delete the theater even if future time slots have reservations",
+                        "name": "force",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -910,6 +1082,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/middleware.HttpError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -919,20 +1097,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/theaters/{theaterID}/rooms/{roomID}/timeslots": {
+        "/theaters/{theaterID}/calendar.ics": {
             "get": {
-                "description": "List time slots",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "iCalendar feed of the theater programme, cancelled time slots are included with a cancelled status. Without a from or date filter the feed starts 30 days in the past",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "timeslots"
+                    "calendars"
                 ],
-                "summary": "List time slots",
-                "operationId": "TimeSlotsList",
+                "summary": "Theater calendar feed",
+                "operationId": "TheaterCalendar",
                 "parameters": [
                     {
                         "type": "string",
@@ -942,62 +1117,247 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Filter by date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by first date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by last date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), inclusive",
+                        "name": "start_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), exclusive",
+                        "name": "start_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated weekdays (monday, ..., sunday)",
+                        "name": "weekday",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/export.csv": {
+            "get": {
+                "description": "Export the theater programme as CSV with one row per time slot, times are in UTC. Without a date or from filter the export covers a week starting today, at most 31 days can be exported",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Export programme as CSV",
+                "operationId": "TheaterExportCSV",
+                "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Room ID",
-                        "name": "roomID",
+                        "description": "Theater ID",
+                        "name": "theaterID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Limit the number of responses",
-                        "name": "limit",
+                        "type": "string",
+                        "format": "date",
+                        "description": "Filter by date (YYYY-MM-DD)",
+                        "name": "date",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset the first response",
-                        "name": "offset",
+                        "type": "string",
+                        "description": "Filter by first date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Sort results",
-                        "name": "sort",
+                        "description": "Filter by last date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), inclusive",
+                        "name": "start_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), exclusive",
+                        "name": "start_before",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Comma separated weekdays (monday, ..., sunday)",
+                        "name": "weekday",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Filter by movie",
+                        "name": "movie_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated statuses, cancelled are excluded by default",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV export",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/export.html": {
+            "get": {
+                "description": "Export the theater programme as a print friendly HTML grid of rooms and days, times are in UTC. Without a date or from filter the export covers a week starting today, at most 31 days can be exported",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Export programme as HTML",
+                "operationId": "TheaterExportHTML",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "format": "date",
                         "description": "Filter by date (YYYY-MM-DD)",
                         "name": "date",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by first date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by last date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), inclusive",
+                        "name": "start_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), exclusive",
+                        "name": "start_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated weekdays (monday, ..., sunday)",
+                        "name": "weekday",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Filter by movie",
+                        "name": "movie_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated statuses, cancelled are excluded by default",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "HTML export",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/request.PaginatedResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/api.TimeSlotResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1021,9 +1381,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}": {
+        "/theaters/{theaterID}/forecast": {
             "get": {
-                "description": "Show time slot",
+                "description": "Predict the occupancy of the scheduled time slots of a theater day that have not started yet and flag the ones likely to stay empty, selling less than 15% of seats",
                 "consumes": [
                     "application/json"
                 ],
@@ -1031,10 +1391,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "timeslots"
+                    "forecasts"
                 ],
-                "summary": "Show time slot",
-                "operationId": "TimeSlotsShow",
+                "summary": "Forecast theater day occupancy",
+                "operationId": "TheaterForecastShow",
                 "parameters": [
                     {
                         "type": "string",
@@ -1046,26 +1406,24 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "Room ID",
-                        "name": "roomID",
-                        "in": "path",
-                        "required": true
+                        "format": "date",
+                        "description": "Day to forecast, the day of at by default",
+                        "name": "date",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "TimeSlot ID",
-                        "name": "timeSlotID",
-                        "in": "path",
-                        "required": true
+                        "format": "date-time",
+                        "description": "Instant to forecast at, now by default",
+                        "name": "at",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.TimeSlotResponse"
+                            "$ref": "#/definitions/api.TheaterForecastResponse"
                         }
                     },
                     "400": {
@@ -1088,187 +1446,4802 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "api.MovieRequest": {
-            "type": "object",
-            "required": [
-                "description",
-                "image_url",
+        },
+        "/theaters/{theaterID}/now": {
+            "get": {
+                "description": "Show the time slot currently running in every room of the theater with its progress and the next upcoming time slots. The ETag leaves out the instant and only changes when the rooms or the progress of their current time slots change, so clients can poll with If-None-Match",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timeslots"
+                ],
+                "summary": "Show now playing",
+                "operationId": "TheaterNow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 10,
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 3,
+                        "description": "Number of upcoming time slots per room",
+                        "name": "next",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Instant to show, defaults to now (RFC 3339)",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TheaterNowResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/now/compact": {
+            "get": {
+                "description": "Compact variant of now playing for signage devices, with only titles, progress and times. Poll it with If-None-Match to receive 304 while nothing changes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timeslots"
+                ],
+                "summary": "Show compact now playing",
+                "operationId": "TheaterNowCompact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 10,
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 3,
+                        "description": "Number of upcoming time slots per room",
+                        "name": "next",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Instant to show, defaults to now (RFC 3339)",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.CompactRoomNowResponse"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/prices": {
+            "get": {
+                "description": "Show the price list of a theater, prices and surcharges are in cents",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "Show price list",
+                "operationId": "PriceListsShow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.PriceListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Create or replace the price list of a theater, prices and surcharges are in cents. Tickets cost the base price of their seat type plus the surcharges for 3D and IMAX rooms, for weekdays or weekends and for the time of day the time slot starts at in UTC",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "Set price list",
+                "operationId": "PriceListsUpdate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.PriceListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.PriceListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete the price list of a theater, its time slots are no longer priced unless their prices are overridden",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "Delete price list",
+                "operationId": "PriceListsDelete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms": {
+            "get": {
+                "description": "List rooms",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "List rooms",
+                "operationId": "RoomsList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit the number of responses",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset the first response",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort results",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated related resources to expand (theater)",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/api.RoomResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create room",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Create room",
+                "operationId": "RoomsCreate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.RoomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.RoomResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}": {
+            "get": {
+                "description": "Show room",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Show room",
+                "operationId": "RoomsShow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated related resources to expand (theater)",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.RoomResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update room. Shrinking the room is refused with a conflict listing the future time slots that have reservations for the removed seats, unless forced, which cancels those time slots. Removed seats with confirmed reservations are disabled and kept for the reservation history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Update room",
+                "operationId": "RoomsUpdate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Cancel time slots with reservations for removed seats",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.RoomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.RoomResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete room and cancel its future time slots. Rooms with reservations for future time slots are not deleted and the reserved time slots are listed in a conflict, unless forced",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Delete room",
+                "operationId": "RoomsDelete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete the room even if future time slots have reservations",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/calendar.ics": {
+            "get": {
+                "description": "iCalendar feed of the room programme, cancelled time slots are included with a cancelled status. Without a from or date filter the feed starts 30 days in the past",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendars"
+                ],
+                "summary": "Room calendar feed",
+                "operationId": "RoomCalendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Filter by date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by first date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by last date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), inclusive",
+                        "name": "start_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), exclusive",
+                        "name": "start_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated weekdays (monday, ..., sunday)",
+                        "name": "weekday",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/seats": {
+            "get": {
+                "description": "Show the seats of a room grouped by row, aisles are not numbered and do not count towards the capacity, neither do disabled seats",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seats"
+                ],
+                "summary": "Show seat map",
+                "operationId": "RoomSeatsShow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SeatMapResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update labels of the given rows and types of the given seats, rows and seats that are not given are kept as they are. Changing the type of seats or disabling them is refused with a conflict listing the future time slots that have reservations for those seats, unless forced, which cancels those time slots",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seats"
+                ],
+                "summary": "Update seat map",
+                "operationId": "RoomSeatsUpdate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Cancel time slots with reservations for changed seats",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SeatMapRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SeatMapResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/seats/plan": {
+            "put": {
+                "description": "Replace the layout of a room with a text seat plan of one line per row and one character per seat: S for standard, V for VIP and W for wheelchair seats, a dot or a space for gaps. The room is resized to the plan, which can be at most 100 by 100 seats, while row labels and disabled seats are kept. Plans that remove or change seats with reservations for future time slots are refused with a conflict listing those time slots, unless forced, which cancels them. Removed seats with confirmed reservations are disabled and kept for the reservation history",
+                "consumes": [
+                    "text/plain"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seats"
+                ],
+                "summary": "Import seat plan",
+                "operationId": "RoomSeatPlanImport",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Cancel time slots with reservations for removed or changed seats",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "description": "seat plan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SeatPlanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/templates": {
+            "get": {
+                "description": "List weekly schedule templates of a room",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "List schedule templates",
+                "operationId": "ScheduleTemplatesList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit the number of responses",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset the first response",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort results",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/api.ScheduleTemplateResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a weekly schedule template for a room. Templates without a movie are filled with an automatically selected movie.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Create schedule template",
+                "operationId": "ScheduleTemplatesCreate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ScheduleTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ScheduleTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/templates/{templateID}": {
+            "get": {
+                "description": "Show schedule template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Show schedule template",
+                "operationId": "ScheduleTemplatesShow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "templateID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ScheduleTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update schedule template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Update schedule template",
+                "operationId": "ScheduleTemplatesUpdate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "templateID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ScheduleTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ScheduleTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete schedule template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Delete schedule template",
+                "operationId": "ScheduleTemplatesDelete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "templateID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/timeslots": {
+            "get": {
+                "description": "List time slots",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timeslots"
+                ],
+                "summary": "List time slots",
+                "operationId": "TimeSlotsList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit the number of responses",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset the first response",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort results",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Filter by date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by first date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by last date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), inclusive",
+                        "name": "start_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), exclusive",
+                        "name": "start_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated weekdays (monday, ..., sunday)",
+                        "name": "weekday",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated statuses (SCHEDULED, CANCELLED, COMPLETED), cancelled time slots are excluded by default",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by whether no seats are available",
+                        "name": "sold_out",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated related resources to expand (movie, room, theater)",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/api.TimeSlotResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/timeslots/shift": {
+            "post": {
+                "description": "Push back every scheduled time slot of the room that starts after the given instant and before closing time of that day by the given number of minutes, keeping the breaks between them. Time slots that would end after closing time are cancelled with overflow DROP, or moved anyway and reported with overflow FLAG (default). Dropping time slots with reservations fails with 409 unless forced. Moving a time slot onto another one fails with 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timeslots"
+                ],
+                "summary": "Shift time slots",
+                "operationId": "TimeSlotsShift",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Drop overflowing time slots even if they have reservations",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeSlotsShiftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeSlotsShiftResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}": {
+            "get": {
+                "description": "Show time slot",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timeslots"
+                ],
+                "summary": "Show time slot",
+                "operationId": "TimeSlotsShow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "TimeSlot ID",
+                        "name": "timeSlotID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated related resources to expand (movie, room, theater)",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeSlotResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/cancel": {
+            "post": {
+                "description": "Cancel a scheduled time slot, the time slot is kept with its cancellation reason. A time slot with reservations is only cancelled with force, otherwise the request fails with 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timeslots"
+                ],
+                "summary": "Cancel time slot",
+                "operationId": "TimeSlotsCancel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "TimeSlot ID",
+                        "name": "timeSlotID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Cancel the time slot even if it has reservations",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeSlotCancelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeSlotResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/forecast": {
+            "get": {
+                "description": "Predict the occupancy of a time slot that has not started yet from the occupancy of past time slots with the same movie, weekday, hour, room size and weeks since release",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "forecasts"
+                ],
+                "summary": "Forecast time slot occupancy",
+                "operationId": "TimeSlotForecastShow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "TimeSlot ID",
+                        "name": "timeSlotID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Instant to forecast at, now by default",
+                        "name": "at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeSlotForecastResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/holds": {
+            "post": {
+                "description": "Hold seats for the user for a time slot until the hold is confirmed, released or expires. Seats that are already held or reserved are listed in the fields of the conflict error",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seats"
+                ],
+                "summary": "Hold seats",
+                "operationId": "SeatHoldsCreate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "TimeSlot ID",
+                        "name": "timeSlotID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SeatHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.SeatHoldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/holds/{holdID}": {
+            "delete": {
+                "description": "Release held seats of a hold of the user, admins can release any hold. Confirmed reservations can not be released, admins cancel them instead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seats"
+                ],
+                "summary": "Release seat hold",
+                "operationId": "SeatHoldsRelease",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "TimeSlot ID",
+                        "name": "timeSlotID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Hold ID",
+                        "name": "holdID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/holds/{holdID}/confirm": {
+            "post": {
+                "description": "Turn held seats into reservations that do not expire once they are paid for, only admins can confirm holds",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seats"
+                ],
+                "summary": "Confirm seat hold",
+                "operationId": "SeatHoldsConfirm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "TimeSlot ID",
+                        "name": "timeSlotID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Hold ID",
+                        "name": "holdID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SeatHoldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/lock": {
+            "post": {
+                "description": "Lock time slot, so that it is never moved or cancelled by automatic rescheduling",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timeslots"
+                ],
+                "summary": "Lock time slot",
+                "operationId": "TimeSlotsLock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "TimeSlot ID",
+                        "name": "timeSlotID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeSlotResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/occupancy": {
+            "put": {
+                "description": "Replace the number of seats the external booking system has sold and held for the time slot, seats reserved through seat holds are counted on top of them, and together they can not exceed the capacity of the room",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timeslots"
+                ],
+                "summary": "Update time slot occupancy",
+                "operationId": "TimeSlotOccupancyUpdate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "TimeSlot ID",
+                        "name": "timeSlotID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeSlotOccupancyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeSlotResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/prices": {
+            "get": {
+                "description": "Evaluate the prices of a time slot for a ticket type. Every seat type gets the lowest price of the promotions that apply, promotions are never combined",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Evaluate time slot prices",
+                "operationId": "TimeSlotPricesEvaluate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "TimeSlot ID",
+                        "name": "timeSlotID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "ADULT",
+                        "description": "Ticket type (ADULT, STUDENT, CHILD, SENIOR)",
+                        "name": "ticket_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.PriceEvaluationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the overridden prices of a time slot, seat types that are not given are priced by the price list of the theater again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "Override time slot prices",
+                "operationId": "TimeSlotPricesUpdate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "TimeSlot ID",
+                        "name": "timeSlotID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeSlotPricesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeSlotResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the overridden prices of a time slot, so that it is priced by the price list of the theater again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "Reset time slot prices",
+                "operationId": "TimeSlotPricesDelete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "TimeSlot ID",
+                        "name": "timeSlotID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/reservations/{holdID}": {
+            "delete": {
+                "description": "Cancel the confirmed reservations of a hold, so that the seats can be booked again. Only reservations for time slots that have not started yet can be cancelled",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seats"
+                ],
+                "summary": "Cancel seat reservation",
+                "operationId": "SeatReservationsCancel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "TimeSlot ID",
+                        "name": "timeSlotID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Hold ID",
+                        "name": "holdID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/seats": {
+            "get": {
+                "description": "Show the seats of a room grouped by row with their availability for the time slot, expired holds are available again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seats"
+                ],
+                "summary": "Show seat availability",
+                "operationId": "TimeSlotSeatsAvailability",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "TimeSlot ID",
+                        "name": "timeSlotID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SeatAvailabilityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/unlock": {
+            "post": {
+                "description": "Unlock time slot",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timeslots"
+                ],
+                "summary": "Unlock time slot",
+                "operationId": "TimeSlotsUnlock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Room ID",
+                        "name": "roomID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "TimeSlot ID",
+                        "name": "timeSlotID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeSlotResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/schedule/copy": {
+            "post": {
+                "description": "Copy the time slots between source_from and source_to (inclusive, a week by default and at most 31 days) so that they start on target_from, which can not be before today or overlap the source range. Slots of inactive movies, movies outside of their release window or outside of the operating days and hours of the room are skipped, and slots overlapping existing ones are reported as conflicts. With dry_run nothing is saved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "Copy theater schedule",
+                "operationId": "ScheduleCopy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ScheduleCopyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ScheduleCopyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/schedule/demand": {
+            "get": {
+                "description": "Show the movie weights the next run of the theater schedule would use, based on the decayed occupancy of the screenings of the last four weeks. Movies without screenings are not listed and weigh 1.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "Show movie demand",
+                "operationId": "ScheduleDemand",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Instant to compute the demand at, now by default",
+                        "name": "at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.MovieDemandResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/schedule/populate": {
+            "post": {
+                "description": "Fill every gap in the theater schedule up to its horizon, instead of only extending newly uncovered days. Movies are picked with weights based on their recent occupancy, the run with the weights used is listed with the schedule runs of the theater.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "Repopulate theater schedule",
+                "operationId": "SchedulePopulate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/schedule/runs": {
+            "get": {
+                "description": "List the runs that populated or extended the theater schedule, newest first, with the movie weights each run was based on",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "List schedule runs",
+                "operationId": "ScheduleRunsList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit the number of responses",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset the first response",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort results",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/api.ScheduleRunResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/schedule/runs/{runID}": {
+            "get": {
+                "description": "Show a run of the theater schedule with the movie weights it was based on",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "Show schedule run",
+                "operationId": "ScheduleRunsShow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Run ID",
+                        "name": "runID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ScheduleRunResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/screenings.jsonld": {
+            "get": {
+                "description": "Upcoming time slots of the theater as schema.org ScreeningEvent structured data, cancelled time slots are included with a cancelled event status",
+                "produces": [
+                    "application/ld+json"
+                ],
+                "tags": [
+                    "theaters"
+                ],
+                "summary": "Theater screenings as JSON-LD",
+                "operationId": "TheaterScreenings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Filter by date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by first date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by last date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), inclusive",
+                        "name": "start_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), exclusive",
+                        "name": "start_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated weekdays (monday, ..., sunday)",
+                        "name": "weekday",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ScreeningEventsJSONLD"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/theaters/{theaterID}/timeslots": {
+            "get": {
+                "description": "List time slots of all rooms in the theater, ordered by start time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timeslots"
+                ],
+                "summary": "List theater time slots",
+                "operationId": "TheaterTimeSlotsList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Theater ID",
+                        "name": "theaterID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit the number of responses",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset the first response",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort results",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Filter by date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by first date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by last date (YYYY-MM-DD) or instant (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), inclusive",
+                        "name": "start_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by start time of day (HH:MM, UTC), exclusive",
+                        "name": "start_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated weekdays (monday, ..., sunday)",
+                        "name": "weekday",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Filter by movie",
+                        "name": "movie_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated statuses (SCHEDULED, CANCELLED, COMPLETED), cancelled time slots are excluded by default",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by whether no seats are available",
+                        "name": "sold_out",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated related resources to expand (room, theater)",
+                        "name": "expand",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/api.TheaterTimeSlotResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.HttpError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.CompactRoomNowResponse": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "next": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.CompactTimeSlotResponse"
+                    }
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "room": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.CompactTimeSlotResponse": {
+            "type": "object",
+            "properties": {
+                "start_time": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.MovieDemandResponse": {
+            "type": "object",
+            "properties": {
+                "movie_id": {
+                    "type": "string"
+                },
+                "occupancy": {
+                    "type": "number"
+                },
+                "screenings": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "api.MovieJSONLD": {
+            "type": "object",
+            "properties": {
+                "@type": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.MovieRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "image_url",
                 "length_minutes",
                 "rating",
                 "title"
             ],
             "properties": {
-                "active": {
+                "active": {
+                    "type": "boolean"
+                },
+                "cancel_future_timeslots": {
+                    "description": "Only used when updating an inactive movie",
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string",
+                    "minLength": 10
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "length_minutes": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 10
+                },
+                "rating": {
+                    "type": "number",
+                    "maximum": 10,
+                    "minimum": 0
+                },
+                "release_date": {
+                    "type": "string",
+                    "format": "date"
+                },
+                "release_end_date": {
+                    "type": "string",
+                    "format": "date"
+                },
+                "title": {
+                    "type": "string",
+                    "minLength": 3
+                }
+            }
+        },
+        "api.MovieResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "length_minutes": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "release_end_date": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.MovieShowtimesResponse": {
+            "type": "object",
+            "properties": {
+                "movie": {
+                    "$ref": "#/definitions/api.MovieSummaryResponse"
+                },
+                "theaters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TheaterShowtimesResponse"
+                    }
+                }
+            }
+        },
+        "api.MovieSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "length_minutes": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                }
+            }
+        },
+        "api.MovieTheaterJSONLD": {
+            "type": "object",
+            "properties": {
+                "@type": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.MovieUpdateResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "length_minutes": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "release_end_date": {
+                    "type": "string"
+                },
+                "schedule_changes": {
+                    "$ref": "#/definitions/api.ScheduleChangesResponse"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.NowPlayingResponse": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "movie": {
+                    "$ref": "#/definitions/api.MovieSummaryResponse"
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.NowTimeSlotResponse": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "movie": {
+                    "$ref": "#/definitions/api.MovieSummaryResponse"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.OccupancyResponse": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "capacity": {
+                    "description": "Number of usable seats of the room",
+                    "type": "integer"
+                },
+                "held": {
+                    "type": "integer"
+                },
+                "sold": {
+                    "type": "integer"
+                },
+                "sold_out": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.PriceEvaluationResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.PromotedPriceResponse"
+                    }
+                },
+                "promotions": {
+                    "description": "Promotions that apply to the time slot and ticket type",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.PromotionResponse"
+                    }
+                },
+                "ticket_type": {
+                    "type": "string",
+                    "enum": [
+                        "ADULT",
+                        "STUDENT",
+                        "CHILD",
+                        "SENIOR"
+                    ]
+                },
+                "time_slot_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.PriceListRequest": {
+            "type": "object",
+            "required": [
+                "standard_price",
+                "vip_price",
+                "wheelchair_price"
+            ],
+            "properties": {
+                "currency": {
+                    "description": "ISO 4217 currency code, EUR by default",
+                    "type": "string"
+                },
+                "imax_surcharge": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "standard_price": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "three_d_surcharge": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "time_of_day_surcharges": {
+                    "type": "array",
+                    "maxItems": 24,
+                    "items": {
+                        "$ref": "#/definitions/api.TimeOfDaySurchargeRequest"
+                    }
+                },
+                "vip_price": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "weekday_surcharge": {
+                    "description": "Negative surcharges are discounts",
+                    "type": "integer"
+                },
+                "weekend_surcharge": {
+                    "type": "integer"
+                },
+                "wheelchair_price": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "api.PriceListResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imax_surcharge": {
+                    "type": "integer"
+                },
+                "standard_price": {
+                    "type": "integer"
+                },
+                "theater_id": {
+                    "type": "string"
+                },
+                "three_d_surcharge": {
+                    "type": "integer"
+                },
+                "time_of_day_surcharges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TimeOfDaySurchargeResponse"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vip_price": {
+                    "type": "integer"
+                },
+                "weekday_surcharge": {
+                    "type": "integer"
+                },
+                "weekend_surcharge": {
+                    "type": "integer"
+                },
+                "wheelchair_price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.PriceTableResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.SeatPriceResponse"
+                    }
+                }
+            }
+        },
+        "api.PromotedPriceResponse": {
+            "type": "object",
+            "properties": {
+                "base_price": {
+                    "description": "Price of the time slot in cents before promotions",
+                    "type": "integer"
+                },
+                "price": {
+                    "description": "Price in cents after the best promotion",
+                    "type": "integer"
+                },
+                "promotion_id": {
+                    "description": "Promotion that gives the price, null when no promotion lowers it",
+                    "type": "string"
+                },
+                "seat_type": {
+                    "type": "string",
+                    "enum": [
+                        "STANDARD",
+                        "VIP",
+                        "WHEELCHAIR"
+                    ]
+                }
+            }
+        },
+        "api.PromotionRequest": {
+            "type": "object",
+            "required": [
+                "discount_type",
+                "discount_value",
+                "name"
+            ],
+            "properties": {
+                "discount_type": {
+                    "description": "PERCENT takes a percentage off, AMOUNT takes cents off and FIXED_PRICE sells for a price in cents",
+                    "type": "string",
+                    "enum": [
+                        "PERCENT",
+                        "AMOUNT",
+                        "FIXED_PRICE"
+                    ]
+                },
+                "discount_value": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "movie_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "start_after": {
+                    "description": "Time of day in UTC that time slots start at or after",
+                    "type": "string"
+                },
+                "start_before": {
+                    "description": "Time of day in UTC that time slots start before",
+                    "type": "string"
+                },
+                "theater_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ticket_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "valid_from": {
+                    "type": "string",
+                    "format": "date"
+                },
+                "valid_until": {
+                    "type": "string",
+                    "format": "date"
+                },
+                "weekdays": {
+                    "description": "ISO weekdays, Monday = 1 ... Sunday = 7",
+                    "type": "array",
+                    "maxItems": 7,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "api.PromotionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "PERCENT",
+                        "AMOUNT",
+                        "FIXED_PRICE"
+                    ]
+                },
+                "discount_value": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "movie_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "start_after": {
+                    "type": "string"
+                },
+                "start_before": {
+                    "type": "string"
+                },
+                "theater_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ticket_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string",
+                    "format": "date"
+                },
+                "valid_until": {
+                    "type": "string",
+                    "format": "date"
+                },
+                "weekdays": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "api.RoomNowResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "$ref": "#/definitions/api.NowPlayingResponse"
+                },
+                "next": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.NowTimeSlotResponse"
+                    }
+                },
+                "room_id": {
+                    "type": "string"
+                },
+                "room_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.RoomRequest": {
+            "type": "object",
+            "required": [
+                "closing_hour",
+                "columns",
+                "name",
+                "opening_hour",
+                "operating_mode",
+                "rows"
+            ],
+            "properties": {
+                "closing_hour": {
+                    "type": "integer",
+                    "maximum": 24,
+                    "minimum": 0
+                },
+                "columns": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "imax": {
+                    "description": "Whether the room is an IMAX room, the current value is kept when omitted",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "opening_hour": {
+                    "type": "integer",
+                    "maximum": 24,
+                    "minimum": 0
+                },
+                "operating_mode": {
+                    "type": "string",
+                    "enum": [
+                        "CLOSED",
+                        "WEEKDAYS",
+                        "WEEKENDS",
+                        "ALL"
+                    ]
+                },
+                "rows": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "schedule_mode": {
+                    "type": "string",
+                    "enum": [
+                        "AUTOMATIC",
+                        "TEMPLATE"
+                    ]
+                },
+                "three_d": {
+                    "description": "Whether the room screens in 3D, the current value is kept when omitted",
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.RoomResponse": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "closing_hour": {
+                    "type": "integer"
+                },
+                "columns": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imax": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opening_hour": {
+                    "type": "integer"
+                },
+                "operating_mode": {
+                    "$ref": "#/definitions/models.RoomOperatingMode"
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "schedule_mode": {
+                    "$ref": "#/definitions/models.RoomScheduleMode"
+                },
+                "theater": {
+                    "$ref": "#/definitions/api.TheaterResponse"
+                },
+                "three_d": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ScheduleChangesResponse": {
+            "type": "object",
+            "properties": {
+                "cancelled": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TimeSlotResponse"
+                    }
+                },
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TimeSlotResponse"
+                    }
+                },
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TimeSlotResponse"
+                    }
+                },
+                "reflowed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TimeSlotResponse"
+                    }
+                },
+                "updated": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TimeSlotResponse"
+                    }
+                }
+            }
+        },
+        "api.ScheduleCopyConflictResponse": {
+            "type": "object",
+            "properties": {
+                "existing": {
+                    "$ref": "#/definitions/api.TimeSlotResponse"
+                },
+                "source": {
+                    "$ref": "#/definitions/api.TimeSlotResponse"
+                }
+            }
+        },
+        "api.ScheduleCopyRequest": {
+            "type": "object",
+            "required": [
+                "source_from",
+                "target_from"
+            ],
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "room_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source_from": {
+                    "type": "string",
+                    "format": "date"
+                },
+                "source_to": {
+                    "type": "string",
+                    "format": "date"
+                },
+                "target_from": {
+                    "type": "string",
+                    "format": "date"
+                }
+            }
+        },
+        "api.ScheduleCopyResponse": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ScheduleCopyConflictResponse"
+                    }
+                },
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TimeSlotResponse"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ScheduleCopySkipResponse"
+                    }
+                }
+            }
+        },
+        "api.ScheduleCopySkipResponse": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "source": {
+                    "$ref": "#/definitions/api.TimeSlotResponse"
+                }
+            }
+        },
+        "api.ScheduleRunResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "demands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.MovieDemandResponse"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/models.ScheduleRunKind"
+                },
+                "rooms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ScheduleRunRoomResponse"
+                    }
+                },
+                "theater_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ScheduleRunRoomResponse": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "exponent": {
+                    "description": "The room was populated with the weights of the run raised to this\nexponent, twice the share of its capacity in the largest capacity of the\ntheater",
+                    "type": "number"
+                },
+                "room_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ScheduleTemplateRequest": {
+            "type": "object",
+            "required": [
+                "start_time",
+                "weekday"
+            ],
+            "properties": {
+                "movie_id": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "type": "integer",
+                    "maximum": 7,
+                    "minimum": 1
+                }
+            }
+        },
+        "api.ScheduleTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "movie_id": {
+                    "type": "string"
+                },
+                "room_id": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.ScreeningEventJSONLD": {
+            "type": "object",
+            "properties": {
+                "@type": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "eventAttendanceMode": {
+                    "type": "string"
+                },
+                "eventStatus": {
+                    "type": "string"
+                },
+                "identifier": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/api.ScreeningRoomJSONLD"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "workPresented": {
+                    "$ref": "#/definitions/api.MovieJSONLD"
+                }
+            }
+        },
+        "api.ScreeningEventsJSONLD": {
+            "type": "object",
+            "properties": {
+                "@context": {
+                    "type": "string"
+                },
+                "@graph": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ScreeningEventJSONLD"
+                    }
+                }
+            }
+        },
+        "api.ScreeningRoomJSONLD": {
+            "type": "object",
+            "properties": {
+                "@type": {
+                    "type": "string"
+                },
+                "containedInPlace": {
+                    "$ref": "#/definitions/api.MovieTheaterJSONLD"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.SeatAvailabilityResponse": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "capacity": {
+                    "type": "integer"
+                },
+                "held": {
+                    "type": "integer"
+                },
+                "reserved": {
+                    "type": "integer"
+                },
+                "seat_rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.SeatRowResponse"
+                    }
+                },
+                "time_slot_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.SeatHoldRequest": {
+            "type": "object",
+            "required": [
+                "seat_ids"
+            ],
+            "properties": {
+                "seat_ids": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ttl_minutes": {
+                    "description": "Minutes until the hold expires, 10 by default",
+                    "type": "integer",
+                    "maximum": 30,
+                    "minimum": 1
+                }
+            }
+        },
+        "api.SeatHoldResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "hold_id": {
+                    "type": "string"
+                },
+                "seat_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "HELD",
+                        "CONFIRMED"
+                    ]
+                },
+                "time_slot_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.SeatMapRequest": {
+            "type": "object",
+            "required": [
+                "seat_rows"
+            ],
+            "properties": {
+                "seat_rows": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/api.SeatRowRequest"
+                    }
+                }
+            }
+        },
+        "api.SeatMapResponse": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "columns": {
+                    "type": "integer"
+                },
+                "room_id": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "seat_rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.SeatRowResponse"
+                    }
+                }
+            }
+        },
+        "api.SeatPlanResponse": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "columns": {
+                    "type": "integer"
+                },
+                "preview": {
+                    "description": "Imported seat plan with row labels, disabled seats are marked with X",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.SeatPriceRequest": {
+            "type": "object",
+            "required": [
+                "seat_type"
+            ],
+            "properties": {
+                "price": {
+                    "description": "Price in cents",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "seat_type": {
+                    "type": "string",
+                    "enum": [
+                        "STANDARD",
+                        "VIP",
+                        "WHEELCHAIR"
+                    ]
+                }
+            }
+        },
+        "api.SeatPriceResponse": {
+            "type": "object",
+            "properties": {
+                "overridden": {
+                    "description": "Whether the price is set for the time slot instead of the price list",
                     "type": "boolean"
                 },
-                "description": {
+                "price": {
+                    "description": "Price in cents",
+                    "type": "integer"
+                },
+                "seat_type": {
                     "type": "string",
-                    "minLength": 10
+                    "enum": [
+                        "STANDARD",
+                        "VIP",
+                        "WHEELCHAIR"
+                    ]
+                }
+            }
+        },
+        "api.SeatRequest": {
+            "type": "object",
+            "required": [
+                "column",
+                "type"
+            ],
+            "properties": {
+                "column": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
                 },
-                "image_url": {
+                "disabled": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "STANDARD",
+                        "VIP",
+                        "WHEELCHAIR",
+                        "AISLE"
+                    ]
+                }
+            }
+        },
+        "api.SeatResponse": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "integer"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "id": {
                     "type": "string"
                 },
-                "length_minutes": {
+                "number": {
+                    "description": "Number of the seat within its row, aisles are not numbered",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Availability of the seat for a time slot",
+                    "type": "string",
+                    "enum": [
+                        "AVAILABLE",
+                        "HELD",
+                        "RESERVED",
+                        "UNAVAILABLE"
+                    ]
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.SeatRowRequest": {
+            "type": "object",
+            "required": [
+                "row"
+            ],
+            "properties": {
+                "label": {
+                    "description": "Label of the row, the current one is kept when empty",
+                    "type": "string",
+                    "maxLength": 3
+                },
+                "row": {
                     "type": "integer",
-                    "maximum": 1000,
-                    "minimum": 10
+                    "maximum": 100,
+                    "minimum": 1
                 },
-                "rating": {
-                    "type": "number",
-                    "maximum": 10,
-                    "minimum": 0
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.SeatRequest"
+                    }
+                }
+            }
+        },
+        "api.SeatRowResponse": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
                 },
-                "title": {
+                "row": {
+                    "type": "integer"
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.SeatResponse"
+                    }
+                }
+            }
+        },
+        "api.ShowtimeDayResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
                     "type": "string",
-                    "minLength": 3
+                    "format": "date"
+                },
+                "showtimes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ShowtimeResponse"
+                    }
                 }
             }
         },
-        "api.MovieResponse": {
+        "api.ShowtimeResponse": {
             "type": "object",
             "properties": {
-                "active": {
-                    "type": "boolean"
-                },
-                "created_at": {
+                "end_time": {
                     "type": "string"
                 },
-                "description": {
+                "id": {
                     "type": "string"
                 },
-                "id": {
+                "room_id": {
                     "type": "string"
                 },
-                "image_url": {
+                "room_name": {
                     "type": "string"
                 },
-                "length_minutes": {
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.TheaterForecastResponse": {
+            "type": "object",
+            "properties": {
+                "capacity": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "date": {
+                    "type": "string",
+                    "format": "date"
                 },
-                "rating": {
+                "likely_empty": {
+                    "description": "Number of time slots that are likely to stay empty",
+                    "type": "integer"
+                },
+                "occupancy": {
                     "type": "number"
                 },
-                "updated_at": {
+                "seats": {
+                    "type": "integer"
+                },
+                "time_slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TimeSlotForecastResponse"
+                    }
+                }
+            }
+        },
+        "api.TheaterNowResponse": {
+            "type": "object",
+            "properties": {
+                "at": {
                     "type": "string"
+                },
+                "rooms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.RoomNowResponse"
+                    }
                 }
             }
         },
-        "api.RoomRequest": {
+        "api.TheaterRequest": {
             "type": "object",
             "required": [
-                "closing_hour",
-                "columns",
-                "name",
-                "opening_hour",
-                "operating_mode",
-                "rows"
+                "name"
             ],
             "properties": {
-                "closing_hour": {
-                    "type": "integer",
-                    "maximum": 24,
-                    "minimum": 0
+                "name": {
+                    "type": "string",
+                    "minLength": 3
                 },
-                "columns": {
+                "schedule_horizon_days": {
                     "type": "integer",
-                    "maximum": 100,
+                    "maximum": 60,
                     "minimum": 1
+                }
+            }
+        },
+        "api.TheaterResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
                 },
                 "name": {
-                    "type": "string",
-                    "minLength": 3
+                    "type": "string"
                 },
-                "opening_hour": {
-                    "type": "integer",
-                    "maximum": 24,
-                    "minimum": 0
+                "rooms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.RoomResponse"
+                    }
                 },
-                "operating_mode": {
-                    "type": "string",
-                    "enum": [
-                        "CLOSED",
-                        "WEEKDAYS",
-                        "WEEKENDS",
-                        "ALL"
-                    ]
+                "schedule_horizon_days": {
+                    "type": "integer"
                 },
-                "rows": {
-                    "type": "integer",
-                    "maximum": 100,
-                    "minimum": 1
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "api.RoomResponse": {
+        "api.TheaterShowtimesResponse": {
             "type": "object",
             "properties": {
-                "closing_hour": {
-                    "type": "integer"
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ShowtimeDayResponse"
+                    }
                 },
-                "columns": {
-                    "type": "integer"
+                "theater_id": {
+                    "type": "string"
+                },
+                "theater_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.TheaterTimeSlotResponse": {
+            "type": "object",
+            "properties": {
+                "cancellation_reason": {
+                    "type": "string"
+                },
+                "cancelled_at": {
+                    "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
+                "end_time": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "locked": {
+                    "type": "boolean"
+                },
+                "movie": {
+                    "$ref": "#/definitions/api.MovieSummaryResponse"
+                },
+                "movie_id": {
                     "type": "string"
                 },
-                "opening_hour": {
-                    "type": "integer"
+                "occupancy": {
+                    "description": "Seats of the time slot, included in listings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.OccupancyResponse"
+                        }
+                    ]
                 },
-                "operating_mode": {
-                    "$ref": "#/definitions/models.RoomOperatingMode"
+                "prices": {
+                    "description": "Resolved ticket prices, omitted when the time slot is not priced",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.PriceTableResponse"
+                        }
+                    ]
                 },
-                "rows": {
-                    "type": "integer"
+                "room": {
+                    "$ref": "#/definitions/api.RoomResponse"
+                },
+                "room_id": {
+                    "type": "string"
+                },
+                "room_name": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.TimeSlotStatus"
+                },
+                "theater": {
+                    "$ref": "#/definitions/api.TheaterResponse"
                 },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "api.TheaterRequest": {
+        "api.TimeOfDaySurchargeRequest": {
             "type": "object",
             "required": [
-                "name"
+                "amount",
+                "to_hour"
             ],
             "properties": {
-                "name": {
+                "amount": {
+                    "description": "Surcharge in cents, negative amounts are discounts",
+                    "type": "integer"
+                },
+                "from_hour": {
+                    "type": "integer",
+                    "maximum": 23,
+                    "minimum": 0
+                },
+                "to_hour": {
+                    "type": "integer",
+                    "maximum": 24,
+                    "minimum": 1
+                }
+            }
+        },
+        "api.TimeOfDaySurchargeResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "from_hour": {
+                    "type": "integer"
+                },
+                "to_hour": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.TimeSlotCancelRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
                     "type": "string",
                     "minLength": 3
                 }
             }
         },
-        "api.TheaterResponse": {
+        "api.TimeSlotForecastResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "likely_empty": {
+                    "type": "boolean"
+                },
+                "movie_id": {
                     "type": "string"
                 },
-                "id": {
+                "occupancy": {
+                    "description": "Predicted share of seats sold, between 0 and 1",
+                    "type": "number"
+                },
+                "room_id": {
                     "type": "string"
                 },
-                "name": {
+                "samples": {
+                    "description": "Number of past time slots the prediction is based on",
+                    "type": "integer"
+                },
+                "seats": {
+                    "description": "Predicted number of seats sold",
+                    "type": "integer"
+                },
+                "start_time": {
                     "type": "string"
                 },
-                "updated_at": {
+                "time_slot_id": {
                     "type": "string"
                 }
             }
         },
+        "api.TimeSlotOccupancyRequest": {
+            "type": "object",
+            "properties": {
+                "held": {
+                    "description": "Seats held by the external booking system",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "sold": {
+                    "description": "Seats sold by the external booking system",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "api.TimeSlotPricesRequest": {
+            "type": "object",
+            "required": [
+                "prices"
+            ],
+            "properties": {
+                "prices": {
+                    "type": "array",
+                    "maxItems": 3,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/api.SeatPriceRequest"
+                    }
+                }
+            }
+        },
         "api.TimeSlotResponse": {
             "type": "object",
             "properties": {
+                "cancellation_reason": {
+                    "type": "string"
+                },
+                "cancelled_at": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
@@ -1278,20 +6251,99 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "locked": {
+                    "type": "boolean"
+                },
+                "movie": {
+                    "$ref": "#/definitions/api.MovieResponse"
+                },
                 "movie_id": {
                     "type": "string"
                 },
+                "occupancy": {
+                    "description": "Seats of the time slot, included in listings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.OccupancyResponse"
+                        }
+                    ]
+                },
+                "prices": {
+                    "description": "Resolved ticket prices, omitted when the time slot is not priced",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.PriceTableResponse"
+                        }
+                    ]
+                },
+                "room": {
+                    "$ref": "#/definitions/api.RoomResponse"
+                },
                 "room_id": {
                     "type": "string"
                 },
                 "start_time": {
                     "type": "string"
                 },
+                "status": {
+                    "$ref": "#/definitions/models.TimeSlotStatus"
+                },
+                "theater": {
+                    "$ref": "#/definitions/api.TheaterResponse"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
+        "api.TimeSlotsShiftRequest": {
+            "type": "object",
+            "required": [
+                "after",
+                "minutes"
+            ],
+            "properties": {
+                "after": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "minutes": {
+                    "type": "integer",
+                    "maximum": 720,
+                    "minimum": 1
+                },
+                "overflow": {
+                    "type": "string",
+                    "enum": [
+                        "DROP",
+                        "FLAG"
+                    ]
+                }
+            }
+        },
+        "api.TimeSlotsShiftResponse": {
+            "type": "object",
+            "properties": {
+                "dropped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TimeSlotResponse"
+                    }
+                },
+                "flagged": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TimeSlotResponse"
+                    }
+                },
+                "moved": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TimeSlotResponse"
+                    }
+                }
+            }
+        },
         "middleware.HttpError": {
             "type": "object",
             "properties": {
@@ -1324,6 +6376,41 @@ const docTemplate = `{
                 "All"
             ]
         },
+        "models.RoomScheduleMode": {
+            "type": "string",
+            "enum": [
+                "AUTOMATIC",
+                "TEMPLATE"
+            ],
+            "x-enum-varnames": [
+                "AutomaticSchedule",
+                "TemplateSchedule"
+            ]
+        },
+        "models.ScheduleRunKind": {
+            "type": "string",
+            "enum": [
+                "POPULATE",
+                "EXTEND"
+            ],
+            "x-enum-varnames": [
+                "PopulateScheduleRun",
+                "ExtendScheduleRun"
+            ]
+        },
+        "models.TimeSlotStatus": {
+            "type": "string",
+            "enum": [
+                "SCHEDULED",
+                "CANCELLED",
+                "COMPLETED"
+            ],
+            "x-enum-varnames": [
+                "ScheduledTimeSlot",
+                "CancelledTimeSlot",
+                "CompletedTimeSlot"
+            ]
+        },
         "request.PaginatedResponse": {
             "type": "object",
             "properties": {
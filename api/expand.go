@@ -0,0 +1,61 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// expandPreloads maps the related resources that can be expanded on an
+// endpoint to the scopes preloading them
+type expandPreloads map[string]func(*gorm.DB) *gorm.DB
+
+var (
+	timeSlotExpandPreloads = expandPreloads{
+		"movie":   models.PreloadScope("Movie"),
+		"room":    models.PreloadScope("Room"),
+		"theater": models.PreloadScope("Room.Theater"),
+	}
+	theaterTimeSlotExpandPreloads = expandPreloads{
+		"room":    models.PreloadScope("Room"),
+		"theater": models.PreloadScope("Room.Theater"),
+	}
+	roomExpandPreloads = expandPreloads{
+		"theater": models.PreloadScope("Theater"),
+	}
+	theaterExpandPreloads = expandPreloads{
+		"rooms": models.PreloadOrderedRoomsScope,
+	}
+)
+
+type expandOptions map[string]bool
+
+// getExpandOptions parses a comma separated list of related resources from the
+// expand query parameter and returns the scopes needed to preload them
+func getExpandOptions(c *gin.Context, preloads expandPreloads) (expandOptions, []func(*gorm.DB) *gorm.DB, error) {
+	expand := expandOptions{}
+	scopes := []func(*gorm.DB) *gorm.DB{}
+
+	query := c.Query("expand")
+	if query == "" {
+		return expand, scopes, nil
+	}
+
+	for _, name := range strings.Split(query, ",") {
+		scope, ok := preloads[name]
+		if !ok {
+			return nil, nil, middleware.NewBadRequestError(fmt.Sprintf("Invalid expand: %s", name))
+		}
+
+		if !expand[name] {
+			expand[name] = true
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return expand, scopes, nil
+}
@@ -0,0 +1,311 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/PRPO-skupina-02/common/request"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// Exports without a date or from filter cover this many days from today
+	defaultExportDays = 7
+	maxExportDays     = 31
+
+	exportDayLayout = "Mon 2006-01-02"
+)
+
+var exportCSVHeader = []string{"theater", "room", "movie", "start", "end"}
+
+var exportTemplate = template.Must(template.New("export").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Theater}} programme {{.From}} - {{.To}}</title>
+<style>
+body { font-family: sans-serif; font-size: 10pt; margin: 1cm; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #444; padding: 4px; text-align: left; vertical-align: top; }
+thead th { background: #eee; }
+.slot { white-space: nowrap; }
+@page { size: A4 landscape; margin: 1cm; }
+@media print { body { margin: 0; } thead { display: table-header-group; } tr { page-break-inside: avoid; } }
+</style>
+</head>
+<body>
+<h1>{{.Theater}}</h1>
+<p>{{.From}} - {{.To}}</p>
+<table>
+<thead>
+<tr><th>Room</th>{{range .Days}}<th>{{.}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{range .Rows}}<tr><th>{{.Room}}</th>{{range .Cells}}<td>{{range .}}<div class="slot">{{.Start}}-{{.End}} {{.Title}}</div>{{end}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+</body>
+</html>
+`))
+
+type exportEntry struct {
+	Start string
+	End   string
+	Title string
+}
+
+type exportRow struct {
+	Room  string
+	Cells [][]exportEntry
+}
+
+type exportGrid struct {
+	Theater string
+	From    string
+	To      string
+	Days    []string
+	Rows    []exportRow
+}
+
+// newExportGrid lays out time slots ordered by start time in a grid of rooms,
+// ordered by name, and the days of [from, to)
+func newExportGrid(theater models.Theater, from, to time.Time, timeSlots []models.TimeSlot) exportGrid {
+	grid := exportGrid{
+		Theater: theater.Name,
+		From:    from.Format(time.DateOnly),
+		To:      to.Add(-time.Nanosecond).Format(time.DateOnly),
+		Days:    []string{},
+		Rows:    []exportRow{},
+	}
+
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	for day := first; day.Before(to); day = day.AddDate(0, 0, 1) {
+		grid.Days = append(grid.Days, day.Format(exportDayLayout))
+	}
+
+	rooms := []models.Room{}
+	cells := map[uuid.UUID][][]exportEntry{}
+
+	for _, timeSlot := range timeSlots {
+		if _, ok := cells[timeSlot.RoomID]; !ok {
+			rooms = append(rooms, timeSlot.Room)
+			cells[timeSlot.RoomID] = make([][]exportEntry, len(grid.Days))
+		}
+
+		start := timeSlot.StartTime.UTC()
+		day := int(start.Sub(first).Hours() / 24)
+		if day < 0 || day >= len(grid.Days) {
+			continue
+		}
+
+		cells[timeSlot.RoomID][day] = append(cells[timeSlot.RoomID][day], exportEntry{
+			Start: start.Format("15:04"),
+			End:   timeSlot.EndTime.UTC().Format("15:04"),
+			Title: timeSlot.Movie.Title,
+		})
+	}
+
+	sort.SliceStable(rooms, func(i, j int) bool {
+		return rooms[i].Name < rooms[j].Name
+	})
+
+	for _, room := range rooms {
+		grid.Rows = append(grid.Rows, exportRow{
+			Room:  room.Name,
+			Cells: cells[room.ID],
+		})
+	}
+
+	return grid
+}
+
+func newExportCSV(theater models.Theater, timeSlots []models.TimeSlot) ([]byte, error) {
+	buffer := bytes.Buffer{}
+	w := csv.NewWriter(&buffer)
+
+	if err := w.Write(exportCSVHeader); err != nil {
+		return nil, err
+	}
+
+	for _, timeSlot := range timeSlots {
+		record := []string{
+			theater.Name,
+			timeSlot.Room.Name,
+			timeSlot.Movie.Title,
+			timeSlot.StartTime.UTC().Format(time.RFC3339),
+			timeSlot.EndTime.UTC().Format(time.RFC3339),
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// getExportRange parses the date or the from and to bounds of an export, which
+// defaults to a week starting today
+func getExportRange(c *gin.Context) (time.Time, time.Time, error) {
+	if query := c.Query("date"); query != "" {
+		date, err := time.Parse(time.DateOnly, query)
+		if err != nil {
+			return time.Time{}, time.Time{}, middleware.NewBadRequestError(fmt.Sprintf("Invalid date: %s", query))
+		}
+		return date, date.AddDate(0, 0, 1), nil
+	}
+
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	if query := c.Query("from"); query != "" {
+		instant, err := parseTimeSlotBound("from", query, false)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = instant.UTC()
+	}
+
+	to := from.AddDate(0, 0, defaultExportDays)
+	if query := c.Query("to"); query != "" {
+		instant, err := parseTimeSlotBound("to", query, true)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = instant.UTC()
+	}
+
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, middleware.NewBadRequestError("Export range must end after it starts")
+	}
+	if to.After(from.AddDate(0, 0, maxExportDays)) {
+		return time.Time{}, time.Time{}, middleware.NewBadRequestError(fmt.Sprintf("Export range must not be longer than %d days", maxExportDays))
+	}
+
+	return from, to, nil
+}
+
+// getExportTimeSlots returns the time slots of the context theater within the
+// export range, filtered like the theater time slot listing
+func getExportTimeSlots(c *gin.Context) ([]models.TimeSlot, time.Time, time.Time, error) {
+	tx := middleware.GetContextTransaction(c)
+	theater := GetContextTheater(c)
+
+	from, to, err := getExportRange(c)
+	if err != nil {
+		return nil, from, to, err
+	}
+
+	filters := []request.Filter{
+		models.TimeRangeFilter{Column: "time_slots.start_time", From: &from, To: &to},
+	}
+
+	for _, parse := range []func(*gin.Context) (request.Filter, error){
+		getTimeSlotTimeOfDayFilter,
+		getTimeSlotWeekdayFilter,
+		getTimeSlotMovieFilter,
+		getTimeSlotStatusFilter,
+	} {
+		filter, err := parse(c)
+		if err != nil {
+			return nil, from, to, err
+		}
+		filters = append(filters, filter)
+	}
+
+	timeSlots, _, err := models.GetTheaterTimeSlots(tx, theater.ID, nil, nil, request.NewFilterOptions(filters...))
+	if err != nil {
+		return nil, from, to, err
+	}
+
+	return timeSlots, from, to, nil
+}
+
+// TheaterExportCSV
+//
+//	@Id				TheaterExportCSV
+//	@Summary		Export programme as CSV
+//	@Description	Export the theater programme as CSV with one row per time slot, times are in UTC. Without a date or from filter the export covers a week starting today, at most 31 days can be exported
+//	@Tags			exports
+//	@Produce		text/csv
+//	@Param			theaterID		path		string	true	"Theater ID"					Format(uuid)
+//	@Param			date			query		string	false	"Filter by date (YYYY-MM-DD)"	Format(date)
+//	@Param			from			query		string	false	"Filter by first date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			to				query		string	false	"Filter by last date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			start_after		query		string	false	"Filter by start time of day (HH:MM, UTC), inclusive"
+//	@Param			start_before	query		string	false	"Filter by start time of day (HH:MM, UTC), exclusive"
+//	@Param			weekday			query		string	false	"Comma separated weekdays (monday, ..., sunday)"
+//	@Param			movie_id		query		string	false	"Filter by movie"	Format(uuid)
+//	@Param			status			query		string	false	"Comma separated statuses, cancelled are excluded by default"
+//	@Success		200				{string}	string	"CSV export"
+//	@Failure		400				{object}	middleware.HttpError
+//	@Failure		404				{object}	middleware.HttpError
+//	@Failure		500				{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/export.csv [get]
+func TheaterExportCSV(c *gin.Context) {
+	theater := GetContextTheater(c)
+
+	timeSlots, _, _, err := getExportTimeSlots(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	body, err := newExportCSV(theater, timeSlots)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=\"programme.csv\"")
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", body)
+}
+
+// TheaterExportHTML
+//
+//	@Id				TheaterExportHTML
+//	@Summary		Export programme as HTML
+//	@Description	Export the theater programme as a print friendly HTML grid of rooms and days, times are in UTC. Without a date or from filter the export covers a week starting today, at most 31 days can be exported
+//	@Tags			exports
+//	@Produce		html
+//	@Param			theaterID		path		string	true	"Theater ID"					Format(uuid)
+//	@Param			date			query		string	false	"Filter by date (YYYY-MM-DD)"	Format(date)
+//	@Param			from			query		string	false	"Filter by first date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			to				query		string	false	"Filter by last date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			start_after		query		string	false	"Filter by start time of day (HH:MM, UTC), inclusive"
+//	@Param			start_before	query		string	false	"Filter by start time of day (HH:MM, UTC), exclusive"
+//	@Param			weekday			query		string	false	"Comma separated weekdays (monday, ..., sunday)"
+//	@Param			movie_id		query		string	false	"Filter by movie"	Format(uuid)
+//	@Param			status			query		string	false	"Comma separated statuses, cancelled are excluded by default"
+//	@Success		200				{string}	string	"HTML export"
+//	@Failure		400				{object}	middleware.HttpError
+//	@Failure		404				{object}	middleware.HttpError
+//	@Failure		500				{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/export.html [get]
+func TheaterExportHTML(c *gin.Context) {
+	theater := GetContextTheater(c)
+
+	timeSlots, from, to, err := getExportTimeSlots(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	body := bytes.Buffer{}
+	if err := exportTemplate.Execute(&body, newExportGrid(theater, from, to, timeSlots)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Data(http.StatusOK, "text/html; charset=utf-8", body.Bytes())
+}
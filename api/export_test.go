@@ -0,0 +1,146 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PRPO-skupina-02/common/database"
+	"github.com/PRPO-skupina-02/common/xtesting"
+	"github.com/PRPO-skupina-02/spored/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTheaterExportCSV(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name      string
+		status    int
+		theaterID string
+		params    string
+	}{
+		{
+			name:      "ok",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?from=2026-01-04&to=2026-01-05",
+		},
+		{
+			name:      "ok-movie",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			params:    "?date=2026-01-03&movie_id=afddb478-e23e-11f0-92e2-3be5b904bf71",
+		},
+		{
+			name:      "ok-no-rooms",
+			status:    http.StatusOK,
+			theaterID: "ea0b7f96-ddc9-11f0-9635-23efd36396bd",
+		},
+		{
+			name:      "invalid-range-too-long",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?from=2026-01-01&to=2026-03-01",
+		},
+		{
+			name:      "invalid-range-reversed",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?from=2026-01-05&to=2026-01-01",
+		},
+		{
+			name:      "invalid-movie-id",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?movie_id=harry",
+		},
+		{
+			name:      "invalid-theater-id",
+			status:    http.StatusNotFound,
+			theaterID: "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:      "nil-theater-id",
+			status:    http.StatusBadRequest,
+			theaterID: "00000000-0000-0000-0000-000000000000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/export.csv%s", testCase.theaterID, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			assertGoldenText(t, w, "text/csv; charset=utf-8")
+		})
+	}
+}
+
+func TestTheaterExportHTML(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name      string
+		status    int
+		theaterID string
+		params    string
+	}{
+		{
+			name:      "ok",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			params:    "?from=2025-12-30&to=2026-01-05",
+		},
+		{
+			name:      "ok-date",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?date=2026-01-04",
+		},
+		{
+			name:      "invalid-date",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?date=tomorrow",
+		},
+		{
+			name:      "invalid-theater-id",
+			status:    http.StatusNotFound,
+			theaterID: "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:      "nil-theater-id",
+			status:    http.StatusBadRequest,
+			theaterID: "00000000-0000-0000-0000-000000000000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/export.html%s", testCase.theaterID, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			assertGoldenText(t, w, "text/html; charset=utf-8")
+		})
+	}
+}
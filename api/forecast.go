@@ -0,0 +1,190 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/PRPO-skupina-02/common/request"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TimeSlotForecastResponse struct {
+	TimeSlotID uuid.UUID `json:"time_slot_id"`
+	RoomID     uuid.UUID `json:"room_id"`
+	MovieID    uuid.UUID `json:"movie_id"`
+	StartTime  time.Time `json:"start_time"`
+	Capacity   int       `json:"capacity"`
+	// Predicted share of seats sold, between 0 and 1
+	Occupancy float64 `json:"occupancy"`
+	// Predicted number of seats sold
+	Seats       int  `json:"seats"`
+	LikelyEmpty bool `json:"likely_empty"`
+	// Number of past time slots the prediction is based on
+	Samples int `json:"samples"`
+}
+
+func newTimeSlotForecastResponse(forecast models.OccupancyForecast) TimeSlotForecastResponse {
+	return TimeSlotForecastResponse{
+		TimeSlotID:  forecast.TimeSlot.ID,
+		RoomID:      forecast.TimeSlot.RoomID,
+		MovieID:     forecast.TimeSlot.MovieID,
+		StartTime:   forecast.TimeSlot.StartTime,
+		Capacity:    forecast.Capacity,
+		Occupancy:   forecast.Occupancy,
+		Seats:       forecast.Seats,
+		LikelyEmpty: forecast.LikelyEmpty,
+		Samples:     forecast.Samples,
+	}
+}
+
+type TheaterForecastResponse struct {
+	Date      string  `json:"date" format:"date"`
+	Capacity  int     `json:"capacity"`
+	Seats     int     `json:"seats"`
+	Occupancy float64 `json:"occupancy"`
+	// Number of time slots that are likely to stay empty
+	LikelyEmpty int                        `json:"likely_empty"`
+	TimeSlots   []TimeSlotForecastResponse `json:"time_slots"`
+}
+
+func newTheaterForecastResponse(day time.Time, forecasts []models.OccupancyForecast) TheaterForecastResponse {
+	response := TheaterForecastResponse{
+		Date:      day.Format(time.DateOnly),
+		TimeSlots: []TimeSlotForecastResponse{},
+	}
+
+	for _, forecast := range forecasts {
+		response.Capacity += forecast.Capacity
+		response.Seats += forecast.Seats
+		if forecast.LikelyEmpty {
+			response.LikelyEmpty++
+		}
+		response.TimeSlots = append(response.TimeSlots, newTimeSlotForecastResponse(forecast))
+	}
+
+	if response.Capacity > 0 {
+		response.Occupancy = math.Round(float64(response.Seats)/float64(response.Capacity)*1000) / 1000
+	}
+
+	return response
+}
+
+// getForecastDay parses the date query parameter, which defaults to the day
+// of the instant
+func getForecastDay(c *gin.Context, instant time.Time) (time.Time, error) {
+	query := c.Query("date")
+	if query == "" {
+		return instant.UTC().Truncate(24 * time.Hour), nil
+	}
+
+	date, err := time.Parse(time.DateOnly, query)
+	if err != nil {
+		return time.Time{}, middleware.NewBadRequestError(fmt.Sprintf("Invalid date: %s", query))
+	}
+
+	return date, nil
+}
+
+// TimeSlotForecastShow
+//
+//	@Id				TimeSlotForecastShow
+//	@Summary		Forecast time slot occupancy
+//	@Description	Predict the occupancy of a time slot that has not started yet from the occupancy of past time slots with the same movie, weekday, hour, room size and weeks since release
+//	@Tags			forecasts
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string	true	"Theater ID"								Format(uuid)
+//	@Param			roomID		path		string	true	"Room ID"									Format(uuid)
+//	@Param			timeSlotID	path		string	true	"TimeSlot ID"								Format(uuid)
+//	@Param			at			query		string	false	"Instant to forecast at, now by default"	Format(date-time)
+//	@Success		200			{object}	TimeSlotForecastResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/forecast [get]
+func TimeSlotForecastShow(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	timeSlotID, err := request.GetUUIDParam(c, "timeSlotID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	instant, err := getNowInstant(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	timeSlot, err := models.GetTimeSlot(tx, room.ID, timeSlotID, models.UnscopedPreloadScope("Movie"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if !timeSlot.StartTime.After(instant) {
+		_ = c.Error(middleware.NewBadRequestError("Only time slots that have not started can be forecast"))
+		return
+	}
+
+	forecasts, err := models.ForecastTimeSlots(tx, []models.TimeSlot{timeSlot}, instant)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTimeSlotForecastResponse(forecasts[0]))
+}
+
+// TheaterForecastShow
+//
+//	@Id				TheaterForecastShow
+//	@Summary		Forecast theater day occupancy
+//	@Description	Predict the occupancy of the scheduled time slots of a theater day that have not started yet and flag the ones likely to stay empty, selling less than 15% of seats
+//	@Tags			forecasts
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string	true	"Theater ID"								Format(uuid)
+//	@Param			date		query		string	false	"Day to forecast, the day of at by default"	Format(date)
+//	@Param			at			query		string	false	"Instant to forecast at, now by default"	Format(date-time)
+//	@Success		200			{object}	TheaterForecastResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/forecast [get]
+func TheaterForecastShow(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	theater := GetContextTheater(c)
+
+	instant, err := getNowInstant(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	day, err := getForecastDay(c, instant)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	timeSlots, err := models.GetTheaterDayForecastTimeSlots(tx, theater.ID, day, instant)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	forecasts, err := models.ForecastTimeSlots(tx, timeSlots, instant)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTheaterForecastResponse(day, forecasts))
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PRPO-skupina-02/common/database"
+	"github.com/PRPO-skupina-02/common/xtesting"
+	"github.com/PRPO-skupina-02/spored/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeSlotForecastShow(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name       string
+		status     int
+		theaterID  string
+		roomID     string
+		timeSlotID string
+		params     string
+	}{
+		{
+			name:       "ok",
+			status:     http.StatusOK,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "e843b5ce-c7ff-45e6-af57-94c88c9b8763",
+			params:     "?at=2026-01-03T00:00:00Z",
+		},
+		{
+			name:       "ok-likely-empty",
+			status:     http.StatusOK,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "83ce8c98-e0ce-4186-834a-94c85ab6d540",
+			params:     "?at=2026-01-04T09:00:00Z",
+		},
+		{
+			name:       "ok-no-history",
+			status:     http.StatusOK,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "7de8288d-964e-4d53-9d41-091e22ce8a6b",
+			params:     "?at=2025-12-01T00:00:00Z",
+		},
+		{
+			name:       "already-started",
+			status:     http.StatusBadRequest,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "7de8288d-964e-4d53-9d41-091e22ce8a6b",
+			params:     "?at=2026-01-03T00:00:00Z",
+		},
+		{
+			name:       "invalid-at",
+			status:     http.StatusBadRequest,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "e843b5ce-c7ff-45e6-af57-94c88c9b8763",
+			params:     "?at=2026-01-03",
+		},
+		{
+			name:       "invalid-timeslot-id",
+			status:     http.StatusNotFound,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "01234567-0123-0123-0123-0123456789ab",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s/timeslots/%s/forecast%s", testCase.theaterID, testCase.roomID, testCase.timeSlotID, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
+
+func TestTheaterForecastShow(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name      string
+		status    int
+		theaterID string
+		params    string
+	}{
+		{
+			name:      "ok",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?at=2026-01-03T00:00:00Z",
+		},
+		{
+			name:      "ok-date",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?at=2026-01-03T00:00:00Z&date=2026-01-05",
+		},
+		{
+			name:      "ok-likely-empty",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			params:    "?at=2026-01-04T09:00:00Z",
+		},
+		{
+			name:      "ok-no-history",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?at=2025-12-01T00:00:00Z&date=2025-12-30",
+		},
+		{
+			name:      "ok-no-rooms",
+			status:    http.StatusOK,
+			theaterID: "ea0b7f96-ddc9-11f0-9635-23efd36396bd",
+			params:    "?at=2026-01-03T00:00:00Z",
+		},
+		{
+			name:      "invalid-date",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?at=2026-01-03T00:00:00Z&date=03.01.2026",
+		},
+		{
+			name:      "invalid-at",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?at=tomorrow",
+		},
+		{
+			name:      "invalid-theater-id",
+			status:    http.StatusNotFound,
+			theaterID: "01234567-0123-0123-0123-0123456789ab",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/forecast%s", testCase.theaterID, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
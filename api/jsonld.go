@@ -0,0 +1,167 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/PRPO-skupina-02/common/request"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	schemaContext = "https://schema.org"
+
+	schemaEventScheduled = "https://schema.org/EventScheduled"
+	schemaEventCancelled = "https://schema.org/EventCancelled"
+	schemaOfflineEvent   = "https://schema.org/OfflineEventAttendanceMode"
+)
+
+type MovieJSONLD struct {
+	Type        string `json:"@type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Image       string `json:"image,omitempty"`
+	Duration    string `json:"duration"`
+}
+
+type MovieTheaterJSONLD struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+type ScreeningRoomJSONLD struct {
+	Type             string             `json:"@type"`
+	Name             string             `json:"name"`
+	ContainedInPlace MovieTheaterJSONLD `json:"containedInPlace"`
+}
+
+type ScreeningEventJSONLD struct {
+	Type                string              `json:"@type"`
+	Identifier          uuid.UUID           `json:"identifier"`
+	Name                string              `json:"name"`
+	StartDate           time.Time           `json:"startDate"`
+	EndDate             time.Time           `json:"endDate"`
+	Duration            string              `json:"duration"`
+	EventStatus         string              `json:"eventStatus"`
+	EventAttendanceMode string              `json:"eventAttendanceMode"`
+	Location            ScreeningRoomJSONLD `json:"location"`
+	WorkPresented       MovieJSONLD         `json:"workPresented"`
+}
+
+type ScreeningEventsJSONLD struct {
+	Context string                 `json:"@context"`
+	Graph   []ScreeningEventJSONLD `json:"@graph"`
+}
+
+// isoDuration formats minutes as an ISO 8601 duration, such as PT2H32M
+func isoDuration(minutes int) string {
+	hours, minutes := minutes/60, minutes%60
+
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("PT%dM", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("PT%dH", hours)
+	default:
+		return fmt.Sprintf("PT%dH%dM", hours, minutes)
+	}
+}
+
+func newScreeningEventsJSONLD(theater models.Theater, timeSlots []models.TimeSlot) ScreeningEventsJSONLD {
+	response := ScreeningEventsJSONLD{
+		Context: schemaContext,
+		Graph:   []ScreeningEventJSONLD{},
+	}
+
+	for _, timeSlot := range timeSlots {
+		status := schemaEventScheduled
+		if timeSlot.Status == models.CancelledTimeSlot {
+			status = schemaEventCancelled
+		}
+
+		duration := isoDuration(timeSlot.Movie.LengthMinutes)
+
+		response.Graph = append(response.Graph, ScreeningEventJSONLD{
+			Type:                "ScreeningEvent",
+			Identifier:          timeSlot.ID,
+			Name:                timeSlot.Movie.Title,
+			StartDate:           timeSlot.StartTime,
+			EndDate:             timeSlot.EndTime,
+			Duration:            duration,
+			EventStatus:         status,
+			EventAttendanceMode: schemaOfflineEvent,
+			Location: ScreeningRoomJSONLD{
+				Type: "Place",
+				Name: timeSlot.Room.Name,
+				ContainedInPlace: MovieTheaterJSONLD{
+					Type: "MovieTheater",
+					Name: theater.Name,
+				},
+			},
+			WorkPresented: MovieJSONLD{
+				Type:        "Movie",
+				Name:        timeSlot.Movie.Title,
+				Description: timeSlot.Movie.Description,
+				Image:       timeSlot.Movie.ImageURL,
+				Duration:    duration,
+			},
+		})
+	}
+
+	return response
+}
+
+// TheaterScreenings
+//
+//	@Id				TheaterScreenings
+//	@Summary		Theater screenings as JSON-LD
+//	@Description	Upcoming time slots of the theater as schema.org ScreeningEvent structured data, cancelled time slots are included with a cancelled event status
+//	@Tags			theaters
+//	@Produce		application/ld+json
+//	@Param			theaterID		path		string	true	"Theater ID"					Format(uuid)
+//	@Param			date			query		string	false	"Filter by date (YYYY-MM-DD)"	Format(date)
+//	@Param			from			query		string	false	"Filter by first date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			to				query		string	false	"Filter by last date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			start_after		query		string	false	"Filter by start time of day (HH:MM, UTC), inclusive"
+//	@Param			start_before	query		string	false	"Filter by start time of day (HH:MM, UTC), exclusive"
+//	@Param			weekday			query		string	false	"Comma separated weekdays (monday, ..., sunday)"
+//	@Success		200				{object}	ScreeningEventsJSONLD
+//	@Failure		400				{object}	middleware.HttpError
+//	@Failure		404				{object}	middleware.HttpError
+//	@Failure		500				{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/screenings.jsonld [get]
+func TheaterScreenings(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	theater := GetContextTheater(c)
+
+	filters, err := getTimeSlotScheduleFilters(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	now := time.Now()
+	filters = append(filters,
+		models.TimeRangeFilter{Column: "time_slots.start_time", From: &now},
+		models.TimeSlotStatusFilter{Statuses: []models.TimeSlotStatus{models.ScheduledTimeSlot, models.CancelledTimeSlot}},
+	)
+
+	timeSlots, _, err := models.GetTheaterTimeSlots(tx, theater.ID, nil, nil, request.NewFilterOptions(filters...))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	body, err := json.Marshal(newScreeningEventsJSONLD(theater, timeSlots))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/ld+json; charset=utf-8", body)
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PRPO-skupina-02/common/database"
+	"github.com/PRPO-skupina-02/common/xtesting"
+	"github.com/PRPO-skupina-02/spored/db"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTheaterScreenings(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name       string
+		status     int
+		theaterID  string
+		params     string
+		cancelSlot string
+	}{
+		{
+			name:      "ok",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+		},
+		{
+			name:       "ok-cancelled",
+			status:     http.StatusOK,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			cancelSlot: "fb5b6883-4f47-49ad-bcb5-6faec96e6ffc",
+		},
+		{
+			name:      "ok-start-after",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			params:    "?date=2099-06-06&start_after=14:00",
+		},
+		{
+			name:      "ok-no-upcoming",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+		},
+		{
+			name:      "invalid-date",
+			status:    http.StatusBadRequest,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			params:    "?date=tomorrow",
+		},
+		{
+			name:      "invalid-theater-id",
+			status:    http.StatusNotFound,
+			theaterID: "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:      "nil-theater-id",
+			status:    http.StatusBadRequest,
+			theaterID: "00000000-0000-0000-0000-000000000000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			if testCase.cancelSlot != "" {
+				err = db.Model(&models.TimeSlot{}).Where("id = ?", testCase.cancelSlot).Update("status", models.CancelledTimeSlot).Error
+				assert.NoError(t, err)
+			}
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/screenings.jsonld%s", testCase.theaterID, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			if w.Code != http.StatusOK {
+				xtesting.AssertGoldenJSON(t, w)
+				return
+			}
+
+			assert.Equal(t, "application/ld+json; charset=utf-8", w.Header().Get("content-type"))
+			xtesting.AssertGoldenJSONWithName(t, w.Body.Bytes(), "")
+		})
+	}
+}
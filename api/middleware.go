@@ -8,10 +8,23 @@ import (
 	"github.com/PRPO-skupina-02/common/request"
 	"github.com/PRPO-skupina-02/spored/models"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// SQLSTATE of unique and exclusion constraint violations
+const (
+	uniqueViolationCode    = "23505"
+	exclusionViolationCode = "23P01"
+)
+
+var conflictMessages = map[string]string{
+	models.TimeSlotOverlapConstraint: "Time slot overlaps another time slot in the room",
+	models.SeatReservationConstraint: "Seats are not available",
+}
+
 const (
 	contextTheaterKey = "theater"
+	contextRoomKey    = "room"
 	contextMovieKey   = "movie"
 )
 
@@ -52,6 +65,40 @@ func TheaterPermissionsMiddleware(c *gin.Context) {
 	c.Next()
 }
 
+func SetContextRoom(c *gin.Context, room models.Room) {
+	c.Set(contextRoomKey, room)
+}
+
+func GetContextRoom(c *gin.Context) models.Room {
+	room, ok := c.Get(contextRoomKey)
+	if !ok {
+		_ = c.AbortWithError(http.StatusInternalServerError, errors.New("Could not get room from context"))
+		return models.Room{}
+	}
+
+	return room.(models.Room)
+}
+
+func RoomContextMiddleware(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	theater := GetContextTheater(c)
+	id, err := request.GetUUIDParam(c, "roomID")
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	room, err := models.GetRoom(tx, theater.ID, id)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusNotFound, err)
+		return
+	}
+
+	SetContextRoom(c, room)
+
+	c.Next()
+}
+
 func SetContextMovie(c *gin.Context, movie models.Movie) {
 	c.Set(contextMovieKey, movie)
 }
@@ -84,3 +131,29 @@ func MovieContextMiddleware(c *gin.Context) {
 
 	c.Next()
 }
+
+// ConflictErrorMiddleware turns unique and exclusion constraint violations into
+// conflict errors, so that middleware.ErrorMiddleware responds with 409
+func ConflictErrorMiddleware(c *gin.Context) {
+	c.Next()
+
+	ginErr := c.Errors.Last()
+	if ginErr == nil {
+		return
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(ginErr.Err, &pgErr) || (pgErr.Code != uniqueViolationCode && pgErr.Code != exclusionViolationCode) {
+		return
+	}
+
+	message, ok := conflictMessages[pgErr.ConstraintName]
+	if !ok {
+		message = "Conflicts with an existing record"
+	}
+
+	ginErr.Err = &middleware.HttpError{
+		Code:    http.StatusConflict,
+		Message: message,
+	}
+}
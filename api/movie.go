@@ -12,28 +12,51 @@ import (
 )
 
 type MovieResponse struct {
-	ID            uuid.UUID `json:"id"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
-	Title         string    `json:"name"`
-	Description   string    `json:"description"`
-	ImageURL      string    `json:"image_url"`
-	Rating        float64   `json:"rating"`
-	LengthMinutes int       `json:"length_minutes"`
-	Active        bool      `json:"active"`
+	ID             uuid.UUID `json:"id"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	Title          string    `json:"name"`
+	Description    string    `json:"description"`
+	ImageURL       string    `json:"image_url"`
+	Rating         float64   `json:"rating"`
+	LengthMinutes  int       `json:"length_minutes"`
+	Active         bool      `json:"active"`
+	ReleaseDate    *string   `json:"release_date"`
+	ReleaseEndDate *string   `json:"release_end_date"`
+}
+
+func formatDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format(time.DateOnly)
+	return &formatted
+}
+
+func parseDate(date string) (*time.Time, error) {
+	if date == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
 }
 
 func newMovieResponse(movie models.Movie) MovieResponse {
 	return MovieResponse{
-		ID:            movie.ID,
-		CreatedAt:     movie.CreatedAt,
-		UpdatedAt:     movie.UpdatedAt,
-		Title:         movie.Title,
-		Description:   movie.Description,
-		ImageURL:      movie.ImageURL,
-		Rating:        movie.Rating,
-		LengthMinutes: movie.LengthMinutes,
-		Active:        movie.Active,
+		ID:             movie.ID,
+		CreatedAt:      movie.CreatedAt,
+		UpdatedAt:      movie.UpdatedAt,
+		Title:          movie.Title,
+		Description:    movie.Description,
+		ImageURL:       movie.ImageURL,
+		Rating:         movie.Rating,
+		LengthMinutes:  movie.LengthMinutes,
+		Active:         movie.Active,
+		ReleaseDate:    formatDate(movie.ReleaseDate),
+		ReleaseEndDate: formatDate(movie.ReleaseEndDate),
 	}
 }
 
@@ -74,12 +97,37 @@ func MoviesList(c *gin.Context) {
 }
 
 type MovieRequest struct {
-	Title         string  `json:"title" binding:"required,min=3"`
-	Description   string  `json:"description" binding:"required,min=10"`
-	ImageURL      string  `json:"image_url" binding:"required,url"`
-	Rating        float64 `json:"rating" binding:"required,min=0,max=10"`
-	LengthMinutes int     `json:"length_minutes" binding:"required,min=10,max=1000"`
-	Active        bool    `json:"active" binding:"boolean"`
+	Title          string  `json:"title" binding:"required,min=3"`
+	Description    string  `json:"description" binding:"required,min=10"`
+	ImageURL       string  `json:"image_url" binding:"required,url"`
+	Rating         float64 `json:"rating" binding:"required,min=0,max=10"`
+	LengthMinutes  int     `json:"length_minutes" binding:"required,min=10,max=1000"`
+	Active         bool    `json:"active" binding:"boolean"`
+	ReleaseDate    string  `json:"release_date" binding:"omitempty,datetime=2006-01-02" format:"date"`
+	ReleaseEndDate string  `json:"release_end_date" binding:"omitempty,datetime=2006-01-02" format:"date"`
+	// Only used when updating an inactive movie
+	CancelFutureTimeSlots bool `json:"cancel_future_timeslots" binding:"boolean"`
+}
+
+func applyMovieReleaseWindow(req MovieRequest, movie *models.Movie) error {
+	releaseDate, err := parseDate(req.ReleaseDate)
+	if err != nil {
+		return err
+	}
+
+	releaseEndDate, err := parseDate(req.ReleaseEndDate)
+	if err != nil {
+		return err
+	}
+
+	if releaseDate != nil && releaseEndDate != nil && releaseEndDate.Before(*releaseDate) {
+		return middleware.NewBadRequestError("Release end date must not be before release date")
+	}
+
+	movie.ReleaseDate = releaseDate
+	movie.ReleaseEndDate = releaseEndDate
+
+	return nil
 }
 
 // MoviesCreate
@@ -116,6 +164,12 @@ func MoviesCreate(c *gin.Context) {
 		Active:        req.Active,
 	}
 
+	err = applyMovieReleaseWindow(req, &movie)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	err = movie.Create(tx)
 	if err != nil {
 		_ = c.Error(err)
@@ -144,32 +198,65 @@ func MoviesShow(c *gin.Context) {
 	c.JSON(http.StatusOK, newMovieResponse(movie))
 }
 
+type ScheduleChangesResponse struct {
+	Updated   []TimeSlotResponse `json:"updated"`
+	Reflowed  []TimeSlotResponse `json:"reflowed"`
+	Conflicts []TimeSlotResponse `json:"conflicts"`
+	Cancelled []TimeSlotResponse `json:"cancelled"`
+	Created   []TimeSlotResponse `json:"created"`
+}
+
+func newScheduleChangesResponse(changes models.ScheduleChanges) ScheduleChangesResponse {
+	return ScheduleChangesResponse{
+		Updated:   newTimeSlotResponses(changes.Updated),
+		Reflowed:  newTimeSlotResponses(changes.Reflowed),
+		Conflicts: newTimeSlotResponses(changes.Conflicts),
+		Cancelled: newTimeSlotResponses(changes.Cancelled),
+		Created:   newTimeSlotResponses(changes.Created),
+	}
+}
+
+type MovieUpdateResponse struct {
+	MovieResponse
+	ScheduleChanges ScheduleChangesResponse `json:"schedule_changes"`
+}
+
 // MoviesUpdate
 //
 //	@Id				MoviesUpdate
 //	@Summary		Update movie
-//	@Description	Update movie
+//	@Description	Update movie. Changing the length recalculates its future time slots and pushes back the time slots following them, time slots that can not be pushed back before closing time or would overlap a locked time slot are cancelled. Deactivating the movie with cancel_future_timeslots cancels its unlocked future time slots and fills the gaps with other movies, time slots with reservations are only cancelled with force, otherwise the update fails with 409. The affected time slots are listed in schedule_changes.
 //	@Tags			movies
 //	@Accept			json
 //	@Produce		json
 //	@Param			movieID	path		string			true	"Movie ID"	Format(uuid)
+//	@Param			force	query		bool			false	"Cancel future time slots with reservations"
 //	@Param			request	body		MovieRequest	true	"request body"
-//	@Success		200		{object}	MovieResponse
+//	@Success		200		{object}	MovieUpdateResponse
 //	@Failure		400		{object}	middleware.HttpError
 //	@Failure		404		{object}	middleware.HttpError
+//	@Failure		409		{object}	middleware.HttpError
 //	@Failure		500		{object}	middleware.HttpError
 //	@Router			/movies/{movieID} [put]
 func MoviesUpdate(c *gin.Context) {
 	tx := middleware.GetContextTransaction(c)
 	movie := GetContextMovie(c)
 
+	force, err := getForce(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	var req MovieRequest
-	err := c.ShouldBindJSON(&req)
+	err = c.ShouldBindJSON(&req)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
+	lengthChanged := movie.LengthMinutes != req.LengthMinutes
+
 	movie.Title = req.Title
 	movie.Description = req.Description
 	movie.ImageURL = req.ImageURL
@@ -177,13 +264,50 @@ func MoviesUpdate(c *gin.Context) {
 	movie.LengthMinutes = req.LengthMinutes
 	movie.Active = req.Active
 
+	err = applyMovieReleaseWindow(req, &movie)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	err = movie.Save(tx)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, newMovieResponse(movie))
+	now := time.Now()
+	changes := models.NewScheduleChanges()
+
+	if !movie.Active && req.CancelFutureTimeSlots {
+		movies, _, err := models.GetMovies(tx, nil, nil)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		changes, err = movie.CancelFutureTimeSlots(tx, now, movies, force)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+
+	if lengthChanged {
+		cascaded, err := movie.CascadeLength(tx, now)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		changes.Updated = cascaded.Updated
+		changes.Reflowed = cascaded.Reflowed
+		changes.Conflicts = cascaded.Conflicts
+	}
+
+	c.JSON(http.StatusOK, MovieUpdateResponse{
+		MovieResponse:   newMovieResponse(movie),
+		ScheduleChanges: newScheduleChangesResponse(changes),
+	})
 }
 
 // MoviesDelete
@@ -94,6 +94,20 @@ func TestMoviesCreate(t *testing.T) {
 			},
 			status: http.StatusBadRequest,
 		},
+		{
+			name: "invalid-release-window",
+			body: MovieRequest{
+				Title:          "TestMovie",
+				Description:    "New Description",
+				ImageURL:       "http://example.com/image.png",
+				Rating:         7.6666,
+				LengthMinutes:  125,
+				Active:         true,
+				ReleaseDate:    "2026-02-01",
+				ReleaseEndDate: "2026-01-01",
+			},
+			status: http.StatusBadRequest,
+		},
 		{
 			name:   "no-body",
 			status: http.StatusBadRequest,
@@ -182,10 +196,13 @@ func TestMoviesUpdate(t *testing.T) {
 	r := TestingRouter(t, db)
 
 	tests := []struct {
-		name   string
-		body   MovieRequest
-		status int
-		id     string
+		name            string
+		body            MovieRequest
+		status          int
+		id              string
+		params          string
+		lockTimeSlot    string
+		ignoreTimeSlots xtesting.ValuesCheckers
 	}{
 		{
 			name: "ok",
@@ -200,6 +217,142 @@ func TestMoviesUpdate(t *testing.T) {
 			status: http.StatusOK,
 			id:     "510633ca-e23f-11f0-a626-d3b8771e2cb9",
 		},
+		{
+			name: "ok-length-cascade",
+			body: MovieRequest{
+				Title:         "Spider-Man: The rise of the Hooks",
+				Description:   "New Description",
+				ImageURL:      "http://example.com/image.png",
+				Rating:        8.4,
+				LengthMinutes: 140,
+				Active:        true,
+			},
+			status: http.StatusOK,
+			id:     "510633ca-e23f-11f0-a626-d3b8771e2cb9",
+		},
+		{
+			name: "ok-length-conflict",
+			body: MovieRequest{
+				Title:         "Spider-Man: The rise of the Hooks",
+				Description:   "New Description",
+				ImageURL:      "http://example.com/image.png",
+				Rating:        8.4,
+				LengthMinutes: 300,
+				Active:        true,
+			},
+			status: http.StatusOK,
+			id:     "510633ca-e23f-11f0-a626-d3b8771e2cb9",
+			ignoreTimeSlots: xtesting.ValuesCheckers{
+				"[35].CancelledAt": xtesting.ValueTime(),
+			},
+		},
+		{
+			name: "ok-length-past-closing",
+			body: MovieRequest{
+				Title:         "Spider-Man: The rise of the Hooks",
+				Description:   "New Description",
+				ImageURL:      "http://example.com/image.png",
+				Rating:        8.4,
+				LengthMinutes: 600,
+				Active:        true,
+			},
+			status: http.StatusOK,
+			id:     "510633ca-e23f-11f0-a626-d3b8771e2cb9",
+			ignoreTimeSlots: xtesting.ValuesCheckers{
+				"[33].CancelledAt": xtesting.ValueTime(),
+			},
+		},
+		{
+			name: "ok-length-conflict-locked",
+			body: MovieRequest{
+				Title:         "Spider-Man: The rise of the Hooks",
+				Description:   "New Description",
+				ImageURL:      "http://example.com/image.png",
+				Rating:        8.4,
+				LengthMinutes: 300,
+				Active:        true,
+			},
+			status:       http.StatusOK,
+			id:           "510633ca-e23f-11f0-a626-d3b8771e2cb9",
+			lockTimeSlot: "79c0586b-eb66-4837-a473-47ed66a59b4c",
+			ignoreTimeSlots: xtesting.ValuesCheckers{
+				"[33].CancelledAt": xtesting.ValueTime(),
+			},
+		},
+		{
+			name: "ok-deactivate-cancel",
+			body: MovieRequest{
+				Title:                 "Spider-Man: The rise of the Hooks",
+				Description:           "New Description",
+				ImageURL:              "http://example.com/image.png",
+				Rating:                8.4,
+				LengthMinutes:         117,
+				Active:                false,
+				CancelFutureTimeSlots: true,
+			},
+			status: http.StatusOK,
+			id:     "510633ca-e23f-11f0-a626-d3b8771e2cb9",
+			ignoreTimeSlots: xtesting.ValuesCheckers{
+				"[33].CancelledAt": xtesting.ValueTime(),
+			},
+		},
+		{
+			name: "ok-deactivate-refill",
+			body: MovieRequest{
+				Title:                 "Harry Potter and the Curse of the REST API",
+				Description:           "A story about a boy living with his abusive aunt and uncle who makes pots for a living.",
+				ImageURL:              "https://image.tmdb.org/t/p/original/qwHFcFIgr4gNCsoS1dCvLoEIxqZ.jpg",
+				Rating:                7.9,
+				LengthMinutes:         152,
+				Active:                false,
+				CancelFutureTimeSlots: true,
+			},
+			status: http.StatusOK,
+			id:     "afddb478-e23e-11f0-92e2-3be5b904bf71",
+			params: "?force=true",
+			ignoreTimeSlots: func() xtesting.ValuesCheckers {
+				checkers := xtesting.ValuesCheckers{}
+				for _, i := range []int{32, 35, 38} {
+					checkers[fmt.Sprintf("[%d].UpdatedAt", i)] = xtesting.ValueTime()
+					checkers[fmt.Sprintf("[%d].CancelledAt", i)] = xtesting.ValueTime()
+				}
+				for _, i := range []int{33, 36, 37} {
+					checkers[fmt.Sprintf("[%d].ID", i)] = xtesting.ValueUUID()
+					checkers[fmt.Sprintf("[%d].CreatedAt", i)] = xtesting.ValueTime()
+					checkers[fmt.Sprintf("[%d].UpdatedAt", i)] = xtesting.ValueTime()
+				}
+				return checkers
+			}(),
+		},
+		{
+			name: "deactivate-reserved",
+			body: MovieRequest{
+				Title:                 "Harry Potter and the Curse of the REST API",
+				Description:           "A story about a boy living with his abusive aunt and uncle who makes pots for a living.",
+				ImageURL:              "https://image.tmdb.org/t/p/original/qwHFcFIgr4gNCsoS1dCvLoEIxqZ.jpg",
+				Rating:                7.9,
+				LengthMinutes:         152,
+				Active:                false,
+				CancelFutureTimeSlots: true,
+			},
+			status: http.StatusConflict,
+			id:     "afddb478-e23e-11f0-92e2-3be5b904bf71",
+		},
+		{
+			name: "invalid-force",
+			body: MovieRequest{
+				Title:                 "Harry Potter and the Curse of the REST API",
+				Description:           "A story about a boy living with his abusive aunt and uncle who makes pots for a living.",
+				ImageURL:              "https://image.tmdb.org/t/p/original/qwHFcFIgr4gNCsoS1dCvLoEIxqZ.jpg",
+				Rating:                7.9,
+				LengthMinutes:         152,
+				Active:                false,
+				CancelFutureTimeSlots: true,
+			},
+			status: http.StatusBadRequest,
+			id:     "afddb478-e23e-11f0-92e2-3be5b904bf71",
+			params: "?force=maybe",
+		},
 		{
 			name: "validation-errors",
 			body: MovieRequest{
@@ -263,7 +416,12 @@ func TestMoviesUpdate(t *testing.T) {
 			err := fixtures.Load()
 			assert.NoError(t, err)
 
-			targetURL := fmt.Sprintf("/api/v1/spored/movies/%s", testCase.id)
+			if testCase.lockTimeSlot != "" {
+				err = db.Model(&models.TimeSlot{}).Where("id = ?", testCase.lockTimeSlot).UpdateColumn("locked", true).Error
+				assert.NoError(t, err)
+			}
+
+			targetURL := fmt.Sprintf("/api/v1/spored/movies/%s%s", testCase.id, testCase.params)
 
 			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPut, testCase.body)
 			assert.NoError(t, err)
@@ -275,11 +433,30 @@ func TestMoviesUpdate(t *testing.T) {
 				"updated_at": xtesting.ValueTimeInPastDuration(time.Second),
 			}
 
+			for _, changes := range []string{"updated", "reflowed", "conflicts", "cancelled", "created"} {
+				for path, checker := range xtesting.GenerateValueCheckersForArrays(map[string]xtesting.ValueChecker{"updated_at": xtesting.ValueTime()}, 4) {
+					ignoreResp["schedule_changes."+changes+"."+path] = checker
+				}
+			}
+			for _, changes := range []string{"conflicts", "cancelled"} {
+				for path, checker := range xtesting.GenerateValueCheckersForArrays(map[string]xtesting.ValueChecker{"cancelled_at": xtesting.ValueTime()}, 4) {
+					ignoreResp["schedule_changes."+changes+"."+path] = checker
+				}
+			}
+			for path, checker := range xtesting.GenerateValueCheckersForArrays(map[string]xtesting.ValueChecker{"id": xtesting.ValueUUID(), "created_at": xtesting.ValueTime()}, 4) {
+				ignoreResp["schedule_changes.created."+path] = checker
+			}
+
 			ignoreMovies := xtesting.GenerateValueCheckersForArrays(map[string]xtesting.ValueChecker{"UpdatedAt": xtesting.ValueTime()}, 10)
+			ignoreTimeSlots := xtesting.GenerateValueCheckersForArraysWithOffset(map[string]xtesting.ValueChecker{"UpdatedAt": xtesting.ValueTime()}, 4, 32)
+			for path, checker := range testCase.ignoreTimeSlots {
+				ignoreTimeSlots[path] = checker
+			}
 
 			assert.Equal(t, testCase.status, w.Code)
 			xtesting.AssertGoldenJSON(t, w, ignoreResp)
 			xtesting.AssertGoldenDatabaseTable(t, db, []models.Movie{}, ignoreMovies)
+			xtesting.AssertGoldenDatabaseTable(t, db.Where("room_id = ?", "e0722c3a-df42-11f0-9579-3734395be62a").Order("start_time").Order("created_at"), []models.TimeSlot{}, ignoreTimeSlots)
 		})
 	}
 }
@@ -0,0 +1,260 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultNowNext = 3
+	maxNowNext     = 10
+)
+
+type NowTimeSlotResponse struct {
+	ID        uuid.UUID            `json:"id"`
+	StartTime time.Time            `json:"start_time"`
+	EndTime   time.Time            `json:"end_time"`
+	Movie     MovieSummaryResponse `json:"movie"`
+}
+
+func newNowTimeSlotResponse(timeSlot models.TimeSlot) NowTimeSlotResponse {
+	return NowTimeSlotResponse{
+		ID:        timeSlot.ID,
+		StartTime: timeSlot.StartTime,
+		EndTime:   timeSlot.EndTime,
+		Movie:     newMovieSummaryResponse(timeSlot.Movie),
+	}
+}
+
+type NowPlayingResponse struct {
+	NowTimeSlotResponse
+	Progress int `json:"progress"`
+}
+
+type RoomNowResponse struct {
+	RoomID   uuid.UUID             `json:"room_id"`
+	RoomName string                `json:"room_name"`
+	Current  *NowPlayingResponse   `json:"current"`
+	Next     []NowTimeSlotResponse `json:"next"`
+}
+
+type TheaterNowResponse struct {
+	At    time.Time         `json:"at"`
+	Rooms []RoomNowResponse `json:"rooms"`
+}
+
+func newTheaterNowResponse(rooms []models.RoomNowPlaying, instant time.Time) TheaterNowResponse {
+	response := TheaterNowResponse{
+		At:    instant,
+		Rooms: []RoomNowResponse{},
+	}
+
+	for _, room := range rooms {
+		roomResponse := RoomNowResponse{
+			RoomID:   room.Room.ID,
+			RoomName: room.Room.Name,
+			Next:     []NowTimeSlotResponse{},
+		}
+
+		if room.Current != nil {
+			roomResponse.Current = &NowPlayingResponse{
+				NowTimeSlotResponse: newNowTimeSlotResponse(*room.Current),
+				Progress:            room.Current.Progress(instant),
+			}
+		}
+
+		for _, timeSlot := range room.Next {
+			roomResponse.Next = append(roomResponse.Next, newNowTimeSlotResponse(timeSlot))
+		}
+
+		response.Rooms = append(response.Rooms, roomResponse)
+	}
+
+	return response
+}
+
+type CompactTimeSlotResponse struct {
+	Title     string    `json:"title"`
+	StartTime time.Time `json:"start_time"`
+}
+
+type CompactRoomNowResponse struct {
+	Room     string                    `json:"room"`
+	Title    *string                   `json:"title"`
+	Progress *int                      `json:"progress"`
+	EndTime  *time.Time                `json:"end_time"`
+	Next     []CompactTimeSlotResponse `json:"next"`
+}
+
+func newCompactRoomNowResponses(rooms []models.RoomNowPlaying, instant time.Time) []CompactRoomNowResponse {
+	response := []CompactRoomNowResponse{}
+
+	for _, room := range rooms {
+		roomResponse := CompactRoomNowResponse{
+			Room: room.Room.Name,
+			Next: []CompactTimeSlotResponse{},
+		}
+
+		if room.Current != nil {
+			progress := room.Current.Progress(instant)
+			roomResponse.Title = &room.Current.Movie.Title
+			roomResponse.Progress = &progress
+			roomResponse.EndTime = &room.Current.EndTime
+		}
+
+		for _, timeSlot := range room.Next {
+			roomResponse.Next = append(roomResponse.Next, CompactTimeSlotResponse{
+				Title:     timeSlot.Movie.Title,
+				StartTime: timeSlot.StartTime,
+			})
+		}
+
+		response = append(response, roomResponse)
+	}
+
+	return response
+}
+
+func getNowInstant(c *gin.Context) (time.Time, error) {
+	query := c.Query("at")
+	if query == "" {
+		return time.Now(), nil
+	}
+
+	instant, err := time.Parse(time.RFC3339, query)
+	if err != nil {
+		return time.Time{}, middleware.NewBadRequestError(fmt.Sprintf("Invalid at instant: %s", query))
+	}
+
+	return instant, nil
+}
+
+func getNowNext(c *gin.Context) (int, error) {
+	query := c.Query("next")
+	if query == "" {
+		return defaultNowNext, nil
+	}
+
+	next, err := strconv.Atoi(query)
+	if err != nil || next < 0 || next > maxNowNext {
+		return 0, middleware.NewBadRequestError(fmt.Sprintf("Next must be a number between 0 and %d", maxNowNext))
+	}
+
+	return next, nil
+}
+
+func getTheaterNowPlaying(c *gin.Context) ([]models.RoomNowPlaying, time.Time, error) {
+	tx := middleware.GetContextTransaction(c)
+	theater := GetContextTheater(c)
+
+	instant, err := getNowInstant(c)
+	if err != nil {
+		return nil, instant, err
+	}
+
+	next, err := getNowNext(c)
+	if err != nil {
+		return nil, instant, err
+	}
+
+	rooms, err := models.GetTheaterNowPlaying(tx, theater.ID, instant, next)
+	if err != nil {
+		return nil, instant, err
+	}
+
+	return rooms, instant, nil
+}
+
+// renderWithETag renders the response with an ETag computed from the tagged
+// value, or responds with 304 when the client already has it
+func renderWithETag(c *gin.Context, tagged any, response any) {
+	body, err := json.Marshal(tagged)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	hash := sha256.Sum256(body)
+	etag := fmt.Sprintf("\"%s\"", hex.EncodeToString(hash[:16]))
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "no-cache")
+
+	for _, match := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+		if strings.TrimSpace(match) == etag {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// TheaterNow
+//
+//	@Id				TheaterNow
+//	@Summary		Show now playing
+//	@Description	Show the time slot currently running in every room of the theater with its progress and the next upcoming time slots. The ETag leaves out the instant and only changes when the rooms or the progress of their current time slots change, so clients can poll with If-None-Match
+//	@Tags			timeslots
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID		path		string	true	"Theater ID"									Format(uuid)
+//	@Param			next			query		int		false	"Number of upcoming time slots per room"		Default(3)	Minimum(0)	Maximum(10)
+//	@Param			at				query		string	false	"Instant to show, defaults to now (RFC 3339)"	Format(date-time)
+//	@Param			If-None-Match	header		string	false	"ETag of a previous response"
+//	@Success		200				{object}	TheaterNowResponse
+//	@Success		304
+//	@Failure		400	{object}	middleware.HttpError
+//	@Failure		404	{object}	middleware.HttpError
+//	@Failure		500	{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/now [get]
+func TheaterNow(c *gin.Context) {
+	rooms, instant, err := getTheaterNowPlaying(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := newTheaterNowResponse(rooms, instant)
+	renderWithETag(c, response.Rooms, response)
+}
+
+// TheaterNowCompact
+//
+//	@Id				TheaterNowCompact
+//	@Summary		Show compact now playing
+//	@Description	Compact variant of now playing for signage devices, with only titles, progress and times. Poll it with If-None-Match to receive 304 while nothing changes
+//	@Tags			timeslots
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID		path	string	true	"Theater ID"									Format(uuid)
+//	@Param			next			query	int		false	"Number of upcoming time slots per room"		Default(3)	Minimum(0)	Maximum(10)
+//	@Param			at				query	string	false	"Instant to show, defaults to now (RFC 3339)"	Format(date-time)
+//	@Param			If-None-Match	header	string	false	"ETag of a previous response"
+//	@Success		200				{array}	CompactRoomNowResponse
+//	@Success		304
+//	@Failure		400	{object}	middleware.HttpError
+//	@Failure		404	{object}	middleware.HttpError
+//	@Failure		500	{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/now/compact [get]
+func TheaterNowCompact(c *gin.Context) {
+	rooms, instant, err := getTheaterNowPlaying(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := newCompactRoomNowResponses(rooms, instant)
+	renderWithETag(c, response, response)
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PRPO-skupina-02/common/database"
+	"github.com/PRPO-skupina-02/common/xtesting"
+	"github.com/PRPO-skupina-02/spored/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTheaterNow(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name        string
+		status      int
+		theaterID   string
+		path        string
+		params      string
+		notModified bool
+	}{
+		{
+			name:      "ok",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			params:    "?at=2026-01-03T15:00:00Z",
+		},
+		{
+			name:      "ok-next",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			params:    "?at=2026-01-03T12:00:00Z&next=1",
+		},
+		{
+			name:      "ok-nothing-running",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?at=2026-01-04T23:30:00Z&next=0",
+		},
+		{
+			name:      "ok-no-rooms",
+			status:    http.StatusOK,
+			theaterID: "ea0b7f96-ddc9-11f0-9635-23efd36396bd",
+			params:    "?at=2026-01-03T15:00:00Z",
+		},
+		{
+			name:      "ok-compact",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			path:      "/compact",
+			params:    "?at=2026-01-03T15:00:00Z&next=2",
+		},
+		{
+			name:        "not-modified",
+			status:      http.StatusNotModified,
+			theaterID:   "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			params:      "?at=2026-01-03T15:00:00Z",
+			notModified: true,
+		},
+		{
+			name:        "not-modified-compact",
+			status:      http.StatusNotModified,
+			theaterID:   "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			path:        "/compact",
+			params:      "?at=2026-01-03T15:00:00Z",
+			notModified: true,
+		},
+		{
+			name:      "invalid-at",
+			status:    http.StatusBadRequest,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			params:    "?at=2026-01-03",
+		},
+		{
+			name:      "invalid-next",
+			status:    http.StatusBadRequest,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			params:    "?next=11",
+		},
+		{
+			name:      "invalid-theater-id",
+			status:    http.StatusNotFound,
+			theaterID: "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:      "nil-theater-id",
+			status:    http.StatusBadRequest,
+			theaterID: "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			name:      "malformed-theater-id",
+			status:    http.StatusBadRequest,
+			theaterID: "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/now%s%s", testCase.theaterID, testCase.path, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			if testCase.notModified {
+				r.ServeHTTP(w, req)
+				assert.Equal(t, http.StatusOK, w.Code)
+
+				etag := w.Header().Get("ETag")
+				assert.NotEmpty(t, etag)
+
+				req = xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+				req.Header.Set("If-None-Match", etag)
+				w = httptest.NewRecorder()
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			if testCase.notModified {
+				assert.Empty(t, w.Body.String())
+				return
+			}
+			if w.Code == http.StatusOK {
+				assert.NotEmpty(t, w.Header().Get("ETag"))
+			}
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
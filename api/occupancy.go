@@ -0,0 +1,94 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/PRPO-skupina-02/common/request"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/gin-gonic/gin"
+)
+
+type TimeSlotOccupancyRequest struct {
+	// Seats sold by the external booking system
+	Sold int `json:"sold" binding:"min=0"`
+	// Seats held by the external booking system
+	Held int `json:"held" binding:"min=0"`
+}
+
+// TimeSlotOccupancyUpdate
+//
+//	@Id				TimeSlotOccupancyUpdate
+//	@Summary		Update time slot occupancy
+//	@Description	Replace the number of seats the external booking system has sold and held for the time slot, seats reserved through seat holds are counted on top of them, and together they can not exceed the capacity of the room
+//	@Tags			timeslots
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string						true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path		string						true	"Room ID"		Format(uuid)
+//	@Param			timeSlotID	path		string						true	"TimeSlot ID"	Format(uuid)
+//	@Param			request		body		TimeSlotOccupancyRequest	true	"request body"
+//	@Success		200			{object}	TimeSlotResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/occupancy [put]
+func TimeSlotOccupancyUpdate(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	timeSlotID, err := request.GetUUIDParam(c, "timeSlotID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req TimeSlotOccupancyRequest
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	timeSlot, err := models.GetTimeSlot(tx, room.ID, timeSlotID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	now := time.Now()
+	reserved, err := models.CountActiveSeatReservations(tx, timeSlot.ID, now)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if req.Sold+req.Held+reserved > room.Capacity {
+		_ = c.Error(middleware.NewBadRequestError(fmt.Sprintf("Occupancy of %d seats and %d reserved seats exceeds the capacity of %d seats", req.Sold+req.Held, reserved, room.Capacity)))
+		return
+	}
+
+	occupancy, err := models.GetOrNewTimeSlotOccupancy(tx, timeSlot.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	occupancy.Sold = req.Sold
+	occupancy.Held = req.Held
+
+	err = occupancy.Save(tx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	occupancies, err := models.ResolveTimeSlotOccupancies(tx, []models.TimeSlot{timeSlot}, now)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTimeSlotResponse(timeSlot).withOccupancy(occupancies))
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PRPO-skupina-02/common/database"
+	"github.com/PRPO-skupina-02/common/xtesting"
+	"github.com/PRPO-skupina-02/spored/db"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func orderedTimeSlotOccupancies(db *gorm.DB) *gorm.DB {
+	return db.Order("time_slot_occupancies.time_slot_id")
+}
+
+func TestTimeSlotOccupancyUpdate(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name              string
+		status            int
+		theaterID         string
+		roomID            string
+		timeSlotID        string
+		body              any
+		ignoreOccupancies xtesting.ValuesCheckers
+	}{
+		{
+			name:       "ok-create",
+			status:     http.StatusOK,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
+			body: TimeSlotOccupancyRequest{
+				Sold: 50,
+				Held: 5,
+			},
+			ignoreOccupancies: xtesting.ValuesCheckers{
+				"[7].ID":        xtesting.ValueUUID(),
+				"[7].CreatedAt": xtesting.ValueTime(),
+				"[7].UpdatedAt": xtesting.ValueTime(),
+			},
+		},
+		{
+			name:       "ok-replace",
+			status:     http.StatusOK,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "7de8288d-964e-4d53-9d41-091e22ce8a6b",
+			body: TimeSlotOccupancyRequest{
+				Sold: 150,
+			},
+			ignoreOccupancies: xtesting.ValuesCheckers{
+				"[5].UpdatedAt": xtesting.ValueTime(),
+			},
+		},
+		{
+			name:       "exceeds-capacity",
+			status:     http.StatusBadRequest,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
+			body: TimeSlotOccupancyRequest{
+				Sold: 80,
+				Held: 1,
+			},
+		},
+		{
+			name:       "exceeds-capacity-with-reservations",
+			status:     http.StatusBadRequest,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
+			body: TimeSlotOccupancyRequest{
+				Sold: 78,
+			},
+		},
+		{
+			name:       "validation-errors",
+			status:     http.StatusBadRequest,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
+			body: TimeSlotOccupancyRequest{
+				Sold: -1,
+				Held: -2,
+			},
+		},
+		{
+			name:       "invalid-timeslot-id",
+			status:     http.StatusNotFound,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "01234567-0123-0123-0123-0123456789ab",
+			body: TimeSlotOccupancyRequest{
+				Sold: 10,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s/timeslots/%s/occupancy", testCase.theaterID, testCase.roomID, testCase.timeSlotID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPut, testCase.body)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+			xtesting.AssertGoldenDatabaseTable(t, orderedTimeSlotOccupancies(db), []models.TimeSlotOccupancy{}, testCase.ignoreOccupancies)
+		})
+	}
+}
@@ -0,0 +1,369 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/PRPO-skupina-02/common/request"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TimeOfDaySurchargeResponse struct {
+	FromHour int `json:"from_hour"`
+	ToHour   int `json:"to_hour"`
+	Amount   int `json:"amount"`
+}
+
+// PriceListResponse lists prices and surcharges in cents
+type PriceListResponse struct {
+	ID                  uuid.UUID                    `json:"id"`
+	CreatedAt           time.Time                    `json:"created_at"`
+	UpdatedAt           time.Time                    `json:"updated_at"`
+	TheaterID           uuid.UUID                    `json:"theater_id"`
+	Currency            string                       `json:"currency"`
+	StandardPrice       int                          `json:"standard_price"`
+	VIPPrice            int                          `json:"vip_price"`
+	WheelchairPrice     int                          `json:"wheelchair_price"`
+	ThreeDSurcharge     int                          `json:"three_d_surcharge"`
+	IMAXSurcharge       int                          `json:"imax_surcharge"`
+	WeekdaySurcharge    int                          `json:"weekday_surcharge"`
+	WeekendSurcharge    int                          `json:"weekend_surcharge"`
+	TimeOfDaySurcharges []TimeOfDaySurchargeResponse `json:"time_of_day_surcharges"`
+}
+
+func newPriceListResponse(priceList models.PriceList) PriceListResponse {
+	response := PriceListResponse{
+		ID:                  priceList.ID,
+		CreatedAt:           priceList.CreatedAt,
+		UpdatedAt:           priceList.UpdatedAt,
+		TheaterID:           priceList.TheaterID,
+		Currency:            priceList.Currency,
+		StandardPrice:       priceList.StandardPrice,
+		VIPPrice:            priceList.VIPPrice,
+		WheelchairPrice:     priceList.WheelchairPrice,
+		ThreeDSurcharge:     priceList.ThreeDSurcharge,
+		IMAXSurcharge:       priceList.IMAXSurcharge,
+		WeekdaySurcharge:    priceList.WeekdaySurcharge,
+		WeekendSurcharge:    priceList.WeekendSurcharge,
+		TimeOfDaySurcharges: []TimeOfDaySurchargeResponse{},
+	}
+
+	for _, surcharge := range priceList.TimeOfDaySurcharges {
+		response.TimeOfDaySurcharges = append(response.TimeOfDaySurcharges, TimeOfDaySurchargeResponse{
+			FromHour: surcharge.FromHour,
+			ToHour:   surcharge.ToHour,
+			Amount:   surcharge.Amount,
+		})
+	}
+
+	return response
+}
+
+type SeatPriceResponse struct {
+	SeatType string `json:"seat_type" enums:"STANDARD,VIP,WHEELCHAIR"`
+	// Price in cents
+	Price int `json:"price"`
+	// Whether the price is set for the time slot instead of the price list
+	Overridden bool `json:"overridden"`
+}
+
+type PriceTableResponse struct {
+	Currency string              `json:"currency"`
+	Prices   []SeatPriceResponse `json:"prices"`
+}
+
+func newPriceTableResponse(table models.PriceTable) PriceTableResponse {
+	response := PriceTableResponse{
+		Currency: table.Currency,
+		Prices:   []SeatPriceResponse{},
+	}
+
+	for _, price := range table.Prices {
+		response.Prices = append(response.Prices, SeatPriceResponse{
+			SeatType:   string(price.SeatType),
+			Price:      price.Price,
+			Overridden: price.Overridden,
+		})
+	}
+
+	return response
+}
+
+// PriceListsShow
+//
+//	@Id				PriceListsShow
+//	@Summary		Show price list
+//	@Description	Show the price list of a theater, prices and surcharges are in cents
+//	@Tags			prices
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string	true	"Theater ID"	Format(uuid)
+//	@Success		200			{object}	PriceListResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/prices [get]
+func PriceListsShow(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	theater := GetContextTheater(c)
+
+	priceList, err := models.GetTheaterPriceList(tx, theater.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newPriceListResponse(priceList))
+}
+
+type TimeOfDaySurchargeRequest struct {
+	FromHour int `json:"from_hour" binding:"min=0,max=23"`
+	ToHour   int `json:"to_hour" binding:"required,min=1,max=24"`
+	// Surcharge in cents, negative amounts are discounts
+	Amount int `json:"amount" binding:"required"`
+}
+
+type PriceListRequest struct {
+	// ISO 4217 currency code, EUR by default
+	Currency        string `json:"currency" binding:"omitempty,len=3,uppercase"`
+	StandardPrice   int    `json:"standard_price" binding:"required,min=1"`
+	VIPPrice        int    `json:"vip_price" binding:"required,min=1"`
+	WheelchairPrice int    `json:"wheelchair_price" binding:"required,min=1"`
+	ThreeDSurcharge int    `json:"three_d_surcharge" binding:"min=0"`
+	IMAXSurcharge   int    `json:"imax_surcharge" binding:"min=0"`
+	// Negative surcharges are discounts
+	WeekdaySurcharge    int                         `json:"weekday_surcharge"`
+	WeekendSurcharge    int                         `json:"weekend_surcharge"`
+	TimeOfDaySurcharges []TimeOfDaySurchargeRequest `json:"time_of_day_surcharges" binding:"omitempty,max=24,dive"`
+}
+
+// PriceListsUpdate
+//
+//	@Id				PriceListsUpdate
+//	@Summary		Set price list
+//	@Description	Create or replace the price list of a theater, prices and surcharges are in cents. Tickets cost the base price of their seat type plus the surcharges for 3D and IMAX rooms, for weekdays or weekends and for the time of day the time slot starts at in UTC
+//	@Tags			prices
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string				true	"Theater ID"	Format(uuid)
+//	@Param			request		body		PriceListRequest	true	"request body"
+//	@Success		200			{object}	PriceListResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/prices [put]
+func PriceListsUpdate(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	theater := GetContextTheater(c)
+
+	var req PriceListRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	surcharges := slices.Clone(req.TimeOfDaySurcharges)
+	slices.SortFunc(surcharges, func(a, b TimeOfDaySurchargeRequest) int {
+		return a.FromHour - b.FromHour
+	})
+
+	for i, surcharge := range surcharges {
+		if surcharge.FromHour >= surcharge.ToHour {
+			_ = c.Error(middleware.NewBadRequestError(fmt.Sprintf("Time of day surcharge from %d to %d is empty", surcharge.FromHour, surcharge.ToHour)))
+			return
+		}
+		if i > 0 && surcharges[i-1].ToHour > surcharge.FromHour {
+			previous := surcharges[i-1]
+			_ = c.Error(middleware.NewBadRequestError(fmt.Sprintf("Time of day surcharges from %d to %d and from %d to %d overlap", previous.FromHour, previous.ToHour, surcharge.FromHour, surcharge.ToHour)))
+			return
+		}
+	}
+
+	priceList, err := models.GetOrNewTheaterPriceList(tx, theater.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	priceList.Currency = models.DefaultCurrency
+	if req.Currency != "" {
+		priceList.Currency = req.Currency
+	}
+	priceList.StandardPrice = req.StandardPrice
+	priceList.VIPPrice = req.VIPPrice
+	priceList.WheelchairPrice = req.WheelchairPrice
+	priceList.ThreeDSurcharge = req.ThreeDSurcharge
+	priceList.IMAXSurcharge = req.IMAXSurcharge
+	priceList.WeekdaySurcharge = req.WeekdaySurcharge
+	priceList.WeekendSurcharge = req.WeekendSurcharge
+
+	priceList.TimeOfDaySurcharges = []models.TimeOfDaySurcharge{}
+	for _, surcharge := range surcharges {
+		priceList.TimeOfDaySurcharges = append(priceList.TimeOfDaySurcharges, models.TimeOfDaySurcharge{
+			ID:       uuid.New(),
+			FromHour: surcharge.FromHour,
+			ToHour:   surcharge.ToHour,
+			Amount:   surcharge.Amount,
+		})
+	}
+
+	err = priceList.Save(tx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newPriceListResponse(priceList))
+}
+
+// PriceListsDelete
+//
+//	@Id				PriceListsDelete
+//	@Summary		Delete price list
+//	@Description	Delete the price list of a theater, its time slots are no longer priced unless their prices are overridden
+//	@Tags			prices
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path	string	true	"Theater ID"	Format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	middleware.HttpError
+//	@Failure		404	{object}	middleware.HttpError
+//	@Failure		500	{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/prices [delete]
+func PriceListsDelete(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	theater := GetContextTheater(c)
+
+	err := models.DeleteTheaterPriceList(tx, theater.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, "")
+}
+
+type SeatPriceRequest struct {
+	SeatType string `json:"seat_type" binding:"required,oneof=STANDARD VIP WHEELCHAIR" enums:"STANDARD,VIP,WHEELCHAIR"`
+	// Price in cents
+	Price int `json:"price" binding:"min=0"`
+}
+
+type TimeSlotPricesRequest struct {
+	Prices []SeatPriceRequest `json:"prices" binding:"required,min=1,max=3,dive"`
+}
+
+// TimeSlotPricesUpdate
+//
+//	@Id				TimeSlotPricesUpdate
+//	@Summary		Override time slot prices
+//	@Description	Replace the overridden prices of a time slot, seat types that are not given are priced by the price list of the theater again
+//	@Tags			prices
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string					true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path		string					true	"Room ID"		Format(uuid)
+//	@Param			timeSlotID	path		string					true	"TimeSlot ID"	Format(uuid)
+//	@Param			request		body		TimeSlotPricesRequest	true	"request body"
+//	@Success		200			{object}	TimeSlotResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/prices [put]
+func TimeSlotPricesUpdate(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	timeSlotID, err := request.GetUUIDParam(c, "timeSlotID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req TimeSlotPricesRequest
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	timeSlot, err := models.GetTimeSlot(tx, room.ID, timeSlotID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	prices := []models.TimeSlotPrice{}
+	for _, price := range req.Prices {
+		seatType := models.SeatType(price.SeatType)
+		if slices.ContainsFunc(prices, func(p models.TimeSlotPrice) bool { return p.SeatType == seatType }) {
+			_ = c.Error(middleware.NewBadRequestError(fmt.Sprintf("Price of %s seats is given more than once", seatType)))
+			return
+		}
+
+		prices = append(prices, models.TimeSlotPrice{
+			ID:       uuid.New(),
+			SeatType: seatType,
+			Price:    price.Price,
+		})
+	}
+
+	err = timeSlot.OverridePrices(tx, prices)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	tables, err := models.ResolveTimeSlotPrices(tx, []models.TimeSlot{timeSlot})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTimeSlotResponse(timeSlot).withPrices(tables))
+}
+
+// TimeSlotPricesDelete
+//
+//	@Id				TimeSlotPricesDelete
+//	@Summary		Reset time slot prices
+//	@Description	Remove the overridden prices of a time slot, so that it is priced by the price list of the theater again
+//	@Tags			prices
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path	string	true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path	string	true	"Room ID"		Format(uuid)
+//	@Param			timeSlotID	path	string	true	"TimeSlot ID"	Format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	middleware.HttpError
+//	@Failure		404	{object}	middleware.HttpError
+//	@Failure		500	{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/prices [delete]
+func TimeSlotPricesDelete(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	timeSlotID, err := request.GetUUIDParam(c, "timeSlotID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	timeSlot, err := models.GetTimeSlot(tx, room.ID, timeSlotID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = timeSlot.ClearPriceOverrides(tx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, "")
+}
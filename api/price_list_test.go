@@ -0,0 +1,427 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/database"
+	"github.com/PRPO-skupina-02/common/xtesting"
+	"github.com/PRPO-skupina-02/spored/db"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func orderedPriceLists(db *gorm.DB) *gorm.DB {
+	return db.Order("price_lists.theater_id")
+}
+
+func orderedTimeOfDaySurcharges(db *gorm.DB) *gorm.DB {
+	return db.Order("time_of_day_surcharges.from_hour")
+}
+
+func orderedTimeSlotPrices(db *gorm.DB) *gorm.DB {
+	return db.Order("time_slot_prices.time_slot_id").Order("time_slot_prices.seat_type")
+}
+
+func TestPriceListsShow(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name      string
+		status    int
+		theaterID string
+	}{
+		{
+			name:      "ok",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+		},
+		{
+			name:      "no-price-list",
+			status:    http.StatusNotFound,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+		},
+		{
+			name:      "invalid-theater-id",
+			status:    http.StatusNotFound,
+			theaterID: "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:      "malformed-theater-id",
+			status:    http.StatusBadRequest,
+			theaterID: "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/prices", testCase.theaterID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
+
+func TestPriceListsUpdate(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name             string
+		status           int
+		theaterID        string
+		body             any
+		ignoreResp       xtesting.ValuesCheckers
+		ignorePriceLists xtesting.ValuesCheckers
+		ignoreSurcharges xtesting.ValuesCheckers
+	}{
+		{
+			name:      "ok-create",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			body: PriceListRequest{
+				StandardPrice:    1000,
+				VIPPrice:         1500,
+				WheelchairPrice:  800,
+				ThreeDSurcharge:  250,
+				IMAXSurcharge:    400,
+				WeekendSurcharge: 150,
+				TimeOfDaySurcharges: []TimeOfDaySurchargeRequest{
+					{FromHour: 18, ToHour: 24, Amount: 200},
+					{FromHour: 10, ToHour: 12, Amount: -100},
+				},
+			},
+			ignoreResp: xtesting.ValuesCheckers{
+				"id":         xtesting.ValueUUID(),
+				"created_at": xtesting.ValueTimeInPastDuration(time.Second),
+				"updated_at": xtesting.ValueTimeInPastDuration(time.Second),
+			},
+			ignorePriceLists: xtesting.ValuesCheckers{
+				"[0].ID":        xtesting.ValueUUID(),
+				"[0].CreatedAt": xtesting.ValueTime(),
+				"[0].UpdatedAt": xtesting.ValueTime(),
+			},
+			ignoreSurcharges: xtesting.ValuesCheckers{
+				"[0].ID":          xtesting.ValueUUID(),
+				"[0].CreatedAt":   xtesting.ValueTime(),
+				"[0].UpdatedAt":   xtesting.ValueTime(),
+				"[0].PriceListID": xtesting.ValueUUID(),
+				"[2].ID":          xtesting.ValueUUID(),
+				"[2].CreatedAt":   xtesting.ValueTime(),
+				"[2].UpdatedAt":   xtesting.ValueTime(),
+				"[2].PriceListID": xtesting.ValueUUID(),
+			},
+		},
+		{
+			name:      "ok-replace",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			body: PriceListRequest{
+				Currency:         "USD",
+				StandardPrice:    1000,
+				VIPPrice:         1600,
+				WheelchairPrice:  800,
+				IMAXSurcharge:    500,
+				WeekdaySurcharge: -100,
+			},
+			ignoreResp: xtesting.ValuesCheckers{
+				"updated_at": xtesting.ValueTimeInPastDuration(time.Second),
+			},
+			ignorePriceLists: xtesting.ValuesCheckers{
+				"[0].UpdatedAt": xtesting.ValueTime(),
+			},
+		},
+		{
+			name:      "overlapping-surcharges",
+			status:    http.StatusBadRequest,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			body: PriceListRequest{
+				StandardPrice:   1000,
+				VIPPrice:        1500,
+				WheelchairPrice: 800,
+				TimeOfDaySurcharges: []TimeOfDaySurchargeRequest{
+					{FromHour: 17, ToHour: 20, Amount: 50},
+					{FromHour: 12, ToHour: 18, Amount: 100},
+				},
+			},
+		},
+		{
+			name:      "empty-surcharge",
+			status:    http.StatusBadRequest,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			body: PriceListRequest{
+				StandardPrice:   1000,
+				VIPPrice:        1500,
+				WheelchairPrice: 800,
+				TimeOfDaySurcharges: []TimeOfDaySurchargeRequest{
+					{FromHour: 20, ToHour: 18, Amount: 100},
+				},
+			},
+		},
+		{
+			name:      "validation-errors",
+			status:    http.StatusBadRequest,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			body: PriceListRequest{
+				Currency:        "eu",
+				VIPPrice:        -5,
+				ThreeDSurcharge: -1,
+				TimeOfDaySurcharges: []TimeOfDaySurchargeRequest{
+					{FromHour: 25, ToHour: 30},
+				},
+			},
+		},
+		{
+			name:      "no-body",
+			status:    http.StatusBadRequest,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+		},
+		{
+			name:      "invalid-theater-id",
+			status:    http.StatusNotFound,
+			theaterID: "01234567-0123-0123-0123-0123456789ab",
+			body: PriceListRequest{
+				StandardPrice:   1000,
+				VIPPrice:        1500,
+				WheelchairPrice: 800,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/prices", testCase.theaterID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPut, testCase.body)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w, testCase.ignoreResp)
+			xtesting.AssertGoldenDatabaseTable(t, orderedPriceLists(db), []models.PriceList{}, testCase.ignorePriceLists)
+			xtesting.AssertGoldenDatabaseTable(t, orderedTimeOfDaySurcharges(db), []models.TimeOfDaySurcharge{}, testCase.ignoreSurcharges)
+		})
+	}
+}
+
+func TestPriceListsDelete(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name      string
+		status    int
+		theaterID string
+	}{
+		{
+			name:      "ok",
+			status:    http.StatusNoContent,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+		},
+		{
+			name:      "no-price-list",
+			status:    http.StatusNotFound,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+		},
+		{
+			name:      "malformed-theater-id",
+			status:    http.StatusBadRequest,
+			theaterID: "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/prices", testCase.theaterID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodDelete, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+			xtesting.AssertGoldenDatabaseTable(t, orderedPriceLists(db), []models.PriceList{}, nil)
+			xtesting.AssertGoldenDatabaseTable(t, orderedTimeOfDaySurcharges(db), []models.TimeOfDaySurcharge{}, nil)
+		})
+	}
+}
+
+func TestTimeSlotPricesUpdate(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name         string
+		status       int
+		theaterID    string
+		roomID       string
+		timeSlotID   string
+		body         any
+		ignorePrices xtesting.ValuesCheckers
+	}{
+		{
+			name:       "ok",
+			status:     http.StatusOK,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "7de8288d-964e-4d53-9d41-091e22ce8a6b",
+			body: TimeSlotPricesRequest{
+				Prices: []SeatPriceRequest{
+					{SeatType: string(models.WheelchairSeat), Price: 0},
+					{SeatType: string(models.VIPSeat), Price: 1200},
+				},
+			},
+			ignorePrices: xtesting.GenerateValueCheckersForArrays(map[string]xtesting.ValueChecker{
+				"ID":        xtesting.ValueUUID(),
+				"CreatedAt": xtesting.ValueTime(),
+				"UpdatedAt": xtesting.ValueTime(),
+			}, 2),
+		},
+		{
+			name:       "ok-without-price-list",
+			status:     http.StatusOK,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			body: TimeSlotPricesRequest{
+				Prices: []SeatPriceRequest{
+					{SeatType: string(models.StandardSeat), Price: 1100},
+				},
+			},
+			ignorePrices: xtesting.GenerateValueCheckersForArraysWithOffset(map[string]xtesting.ValueChecker{
+				"ID":        xtesting.ValueUUID(),
+				"CreatedAt": xtesting.ValueTime(),
+				"UpdatedAt": xtesting.ValueTime(),
+			}, 1, 1),
+		},
+		{
+			name:       "duplicate-seat-type",
+			status:     http.StatusBadRequest,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "7de8288d-964e-4d53-9d41-091e22ce8a6b",
+			body: TimeSlotPricesRequest{
+				Prices: []SeatPriceRequest{
+					{SeatType: string(models.VIPSeat), Price: 1200},
+					{SeatType: string(models.VIPSeat), Price: 1300},
+				},
+			},
+		},
+		{
+			name:       "validation-errors",
+			status:     http.StatusBadRequest,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "7de8288d-964e-4d53-9d41-091e22ce8a6b",
+			body: TimeSlotPricesRequest{
+				Prices: []SeatPriceRequest{
+					{SeatType: string(models.AisleSeat), Price: -1},
+				},
+			},
+		},
+		{
+			name:       "no-body",
+			status:     http.StatusBadRequest,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "7de8288d-964e-4d53-9d41-091e22ce8a6b",
+		},
+		{
+			name:       "invalid-timeslot-id",
+			status:     http.StatusNotFound,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "01234567-0123-0123-0123-0123456789ab",
+			body: TimeSlotPricesRequest{
+				Prices: []SeatPriceRequest{
+					{SeatType: string(models.VIPSeat), Price: 1200},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s/timeslots/%s/prices", testCase.theaterID, testCase.roomID, testCase.timeSlotID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPut, testCase.body)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+			xtesting.AssertGoldenDatabaseTable(t, orderedTimeSlotPrices(db), []models.TimeSlotPrice{}, testCase.ignorePrices)
+		})
+	}
+}
+
+func TestTimeSlotPricesDelete(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name       string
+		status     int
+		timeSlotID string
+	}{
+		{
+			name:       "ok",
+			status:     http.StatusNoContent,
+			timeSlotID: "7de8288d-964e-4d53-9d41-091e22ce8a6b",
+		},
+		{
+			name:       "invalid-timeslot-id",
+			status:     http.StatusNotFound,
+			timeSlotID: "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:       "malformed-timeslot-id",
+			status:     http.StatusBadRequest,
+			timeSlotID: "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/fb126c8c-d059-11f0-8fa4-b35f33be83b7/rooms/ec19b8aa-df42-11f0-9018-53ba2f5e5e7c/timeslots/%s/prices", testCase.timeSlotID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodDelete, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+			xtesting.AssertGoldenDatabaseTable(t, orderedTimeSlotPrices(db), []models.TimeSlotPrice{}, nil)
+		})
+	}
+}
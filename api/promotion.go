@@ -0,0 +1,449 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/PRPO-skupina-02/common/request"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PromotionResponse struct {
+	ID            uuid.UUID   `json:"id"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+	Name          string      `json:"name"`
+	Weekdays      []int       `json:"weekdays"`
+	StartAfter    *string     `json:"start_after"`
+	StartBefore   *string     `json:"start_before"`
+	MovieIDs      []uuid.UUID `json:"movie_ids"`
+	TheaterIDs    []uuid.UUID `json:"theater_ids"`
+	TicketTypes   []string    `json:"ticket_types"`
+	ValidFrom     *string     `json:"valid_from" format:"date"`
+	ValidUntil    *string     `json:"valid_until" format:"date"`
+	DiscountType  string      `json:"discount_type" enums:"PERCENT,AMOUNT,FIXED_PRICE"`
+	DiscountValue int         `json:"discount_value"`
+}
+
+func newPromotionResponse(promotion models.Promotion) PromotionResponse {
+	response := PromotionResponse{
+		ID:            promotion.ID,
+		CreatedAt:     promotion.CreatedAt,
+		UpdatedAt:     promotion.UpdatedAt,
+		Name:          promotion.Name,
+		Weekdays:      []int{},
+		StartAfter:    promotion.StartAfter,
+		StartBefore:   promotion.StartBefore,
+		MovieIDs:      []uuid.UUID{},
+		TheaterIDs:    []uuid.UUID{},
+		TicketTypes:   []string{},
+		ValidFrom:     formatDate(promotion.ValidFrom),
+		ValidUntil:    formatDate(promotion.ValidUntil),
+		DiscountType:  string(promotion.DiscountType),
+		DiscountValue: promotion.DiscountValue,
+	}
+
+	response.Weekdays = append(response.Weekdays, promotion.Weekdays...)
+	response.MovieIDs = append(response.MovieIDs, promotion.MovieIDs...)
+	response.TheaterIDs = append(response.TheaterIDs, promotion.TheaterIDs...)
+	for _, ticketType := range promotion.TicketTypes {
+		response.TicketTypes = append(response.TicketTypes, string(ticketType))
+	}
+
+	return response
+}
+
+// PromotionsList
+//
+//	@Id				PromotionsList
+//	@Summary		List promotions
+//	@Description	List promotions ordered by name
+//	@Tags			promotions
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit	query		int		false	"Limit the number of responses"	Default(10)
+//	@Param			offset	query		int		false	"Offset the first response"		Default(0)
+//	@Param			sort	query		string	false	"Sort results"
+//	@Success		200		{object}	request.PaginatedResponse{data=[]PromotionResponse}
+//	@Failure		400		{object}	middleware.HttpError
+//	@Failure		404		{object}	middleware.HttpError
+//	@Failure		500		{object}	middleware.HttpError
+//	@Router			/promotions [get]
+func PromotionsList(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	pagination := request.GetNormalizedPaginationArgs(c)
+	sort := request.GetSortOptions(c)
+
+	promotions, total, err := models.GetPromotions(tx, pagination, sort)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := []PromotionResponse{}
+
+	for _, promotion := range promotions {
+		response = append(response, newPromotionResponse(promotion))
+	}
+
+	request.RenderPaginatedResponse(c, response, total)
+}
+
+// PromotionsShow
+//
+//	@Id				PromotionsShow
+//	@Summary		Show promotion
+//	@Description	Show promotion
+//	@Tags			promotions
+//	@Accept			json
+//	@Produce		json
+//	@Param			promotionID	path		string	true	"Promotion ID"	Format(uuid)
+//	@Success		200			{object}	PromotionResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/promotions/{promotionID} [get]
+func PromotionsShow(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	id, err := request.GetUUIDParam(c, "promotionID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	promotion, err := models.GetPromotion(tx, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newPromotionResponse(promotion))
+}
+
+type PromotionRequest struct {
+	Name string `json:"name" binding:"required,min=3"`
+	// ISO weekdays, Monday = 1 ... Sunday = 7
+	Weekdays []int `json:"weekdays" binding:"omitempty,max=7,dive,min=1,max=7"`
+	// Time of day in UTC that time slots start at or after
+	StartAfter string `json:"start_after" binding:"omitempty,datetime=15:04"`
+	// Time of day in UTC that time slots start before
+	StartBefore string   `json:"start_before" binding:"omitempty,datetime=15:04"`
+	MovieIDs    []string `json:"movie_ids" binding:"omitempty,dive,uuid,non-nil-uuid"`
+	TheaterIDs  []string `json:"theater_ids" binding:"omitempty,dive,uuid,non-nil-uuid"`
+	TicketTypes []string `json:"ticket_types" binding:"omitempty,dive,oneof=ADULT STUDENT CHILD SENIOR"`
+	ValidFrom   string   `json:"valid_from" binding:"omitempty,datetime=2006-01-02" format:"date"`
+	ValidUntil  string   `json:"valid_until" binding:"omitempty,datetime=2006-01-02" format:"date"`
+	// PERCENT takes a percentage off, AMOUNT takes cents off and FIXED_PRICE sells for a price in cents
+	DiscountType  string `json:"discount_type" binding:"required,oneof=PERCENT AMOUNT FIXED_PRICE" enums:"PERCENT,AMOUNT,FIXED_PRICE"`
+	DiscountValue int    `json:"discount_value" binding:"required,min=1"`
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func applyPromotionRequest(c *gin.Context, promotion *models.Promotion) error {
+	tx := middleware.GetContextTransaction(c)
+
+	var req PromotionRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		return err
+	}
+
+	if req.DiscountType == string(models.PercentDiscount) && req.DiscountValue > 100 {
+		return middleware.NewBadRequestError("Percent discounts must not be more than 100")
+	}
+
+	if req.StartAfter != "" && req.StartBefore != "" && req.StartBefore <= req.StartAfter {
+		return middleware.NewBadRequestError("Start before time must be after start after time")
+	}
+
+	validFrom, err := parseDate(req.ValidFrom)
+	if err != nil {
+		return err
+	}
+
+	validUntil, err := parseDate(req.ValidUntil)
+	if err != nil {
+		return err
+	}
+
+	if validFrom != nil && validUntil != nil && validUntil.Before(*validFrom) {
+		return middleware.NewBadRequestError("Valid until date must not be before valid from date")
+	}
+
+	promotion.MovieIDs = []uuid.UUID{}
+	for _, movieID := range req.MovieIDs {
+		movie, err := models.GetMovie(tx, uuid.MustParse(movieID))
+		if err != nil {
+			return err
+		}
+		promotion.MovieIDs = append(promotion.MovieIDs, movie.ID)
+	}
+
+	promotion.TheaterIDs = []uuid.UUID{}
+	for _, theaterID := range req.TheaterIDs {
+		theater, err := models.GetTheater(tx, uuid.MustParse(theaterID))
+		if err != nil {
+			return err
+		}
+		promotion.TheaterIDs = append(promotion.TheaterIDs, theater.ID)
+	}
+
+	promotion.TicketTypes = []models.TicketType{}
+	for _, ticketType := range req.TicketTypes {
+		promotion.TicketTypes = append(promotion.TicketTypes, models.TicketType(ticketType))
+	}
+
+	promotion.Name = req.Name
+	promotion.Weekdays = append([]int{}, req.Weekdays...)
+	promotion.StartAfter = optionalString(req.StartAfter)
+	promotion.StartBefore = optionalString(req.StartBefore)
+	promotion.ValidFrom = validFrom
+	promotion.ValidUntil = validUntil
+	promotion.DiscountType = models.DiscountType(req.DiscountType)
+	promotion.DiscountValue = req.DiscountValue
+
+	return nil
+}
+
+// PromotionsCreate
+//
+//	@Id				PromotionsCreate
+//	@Summary		Create promotion
+//	@Description	Create a promotion that discounts tickets of time slots meeting all of its conditions, conditions that are left empty match every time slot
+//	@Tags			promotions
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		PromotionRequest	true	"request body"
+//	@Success		201		{object}	PromotionResponse
+//	@Failure		400		{object}	middleware.HttpError
+//	@Failure		404		{object}	middleware.HttpError
+//	@Failure		500		{object}	middleware.HttpError
+//	@Router			/promotions [post]
+func PromotionsCreate(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+
+	promotion := models.Promotion{
+		ID: uuid.New(),
+	}
+
+	err := applyPromotionRequest(c, &promotion)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = promotion.Create(tx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newPromotionResponse(promotion))
+}
+
+// PromotionsUpdate
+//
+//	@Id				PromotionsUpdate
+//	@Summary		Update promotion
+//	@Description	Update promotion
+//	@Tags			promotions
+//	@Accept			json
+//	@Produce		json
+//	@Param			promotionID	path		string				true	"Promotion ID"	Format(uuid)
+//	@Param			request		body		PromotionRequest	true	"request body"
+//	@Success		200			{object}	PromotionResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/promotions/{promotionID} [put]
+func PromotionsUpdate(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	id, err := request.GetUUIDParam(c, "promotionID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	promotion, err := models.GetPromotion(tx, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = applyPromotionRequest(c, &promotion)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = promotion.Save(tx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newPromotionResponse(promotion))
+}
+
+// PromotionsDelete
+//
+//	@Id				PromotionsDelete
+//	@Summary		Delete promotion
+//	@Description	Delete promotion
+//	@Tags			promotions
+//	@Accept			json
+//	@Produce		json
+//	@Param			promotionID	path	string	true	"Promotion ID"	Format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	middleware.HttpError
+//	@Failure		404	{object}	middleware.HttpError
+//	@Failure		500	{object}	middleware.HttpError
+//	@Router			/promotions/{promotionID} [delete]
+func PromotionsDelete(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	id, err := request.GetUUIDParam(c, "promotionID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = models.DeletePromotion(tx, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, "")
+}
+
+type PromotedPriceResponse struct {
+	SeatType string `json:"seat_type" enums:"STANDARD,VIP,WHEELCHAIR"`
+	// Price of the time slot in cents before promotions
+	BasePrice int `json:"base_price"`
+	// Price in cents after the best promotion
+	Price int `json:"price"`
+	// Promotion that gives the price, null when no promotion lowers it
+	PromotionID *uuid.UUID `json:"promotion_id"`
+}
+
+type PriceEvaluationResponse struct {
+	TimeSlotID uuid.UUID               `json:"time_slot_id"`
+	TicketType string                  `json:"ticket_type" enums:"ADULT,STUDENT,CHILD,SENIOR"`
+	Currency   string                  `json:"currency"`
+	Prices     []PromotedPriceResponse `json:"prices"`
+	// Promotions that apply to the time slot and ticket type
+	Promotions []PromotionResponse `json:"promotions"`
+}
+
+func newPriceEvaluationResponse(timeSlot models.TimeSlot, ticketType models.TicketType, table models.PriceTable, promotions []models.Promotion) PriceEvaluationResponse {
+	response := PriceEvaluationResponse{
+		TimeSlotID: timeSlot.ID,
+		TicketType: string(ticketType),
+		Currency:   table.Currency,
+		Prices:     []PromotedPriceResponse{},
+		Promotions: []PromotionResponse{},
+	}
+
+	for _, price := range models.ApplyPromotions(table, promotions) {
+		var promotionID *uuid.UUID
+		if price.Promotion != nil {
+			promotionID = &price.Promotion.ID
+		}
+
+		response.Prices = append(response.Prices, PromotedPriceResponse{
+			SeatType:    string(price.SeatType),
+			BasePrice:   price.BasePrice,
+			Price:       price.Price,
+			PromotionID: promotionID,
+		})
+	}
+
+	for _, promotion := range promotions {
+		response.Promotions = append(response.Promotions, newPromotionResponse(promotion))
+	}
+
+	return response
+}
+
+func getTicketType(c *gin.Context) (models.TicketType, error) {
+	query := c.Query("ticket_type")
+	if query == "" {
+		return models.AdultTicket, nil
+	}
+
+	switch models.TicketType(query) {
+	case models.AdultTicket, models.StudentTicket, models.ChildTicket, models.SeniorTicket:
+		return models.TicketType(query), nil
+	default:
+		return "", middleware.NewBadRequestError(fmt.Sprintf("Invalid ticket_type: %s", query))
+	}
+}
+
+// TimeSlotPricesEvaluate
+//
+//	@Id				TimeSlotPricesEvaluate
+//	@Summary		Evaluate time slot prices
+//	@Description	Evaluate the prices of a time slot for a ticket type. Every seat type gets the lowest price of the promotions that apply, promotions are never combined
+//	@Tags			promotions
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string	true	"Theater ID"									Format(uuid)
+//	@Param			roomID		path		string	true	"Room ID"										Format(uuid)
+//	@Param			timeSlotID	path		string	true	"TimeSlot ID"									Format(uuid)
+//	@Param			ticket_type	query		string	false	"Ticket type (ADULT, STUDENT, CHILD, SENIOR)"	Default(ADULT)
+//	@Success		200			{object}	PriceEvaluationResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/prices [get]
+func TimeSlotPricesEvaluate(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	timeSlotID, err := request.GetUUIDParam(c, "timeSlotID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	ticketType, err := getTicketType(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	timeSlot, err := models.GetTimeSlot(tx, room.ID, timeSlotID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	tables, err := models.ResolveTimeSlotPrices(tx, []models.TimeSlot{timeSlot})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	table, ok := tables[timeSlot.ID]
+	if !ok {
+		_ = c.Error(middleware.NewNamedNotFoundError("Prices"))
+		return
+	}
+
+	promotions, err := models.GetApplicablePromotions(tx, timeSlot, room.TheaterID, ticketType)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newPriceEvaluationResponse(timeSlot, ticketType, table, promotions))
+}
@@ -0,0 +1,396 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/database"
+	"github.com/PRPO-skupina-02/common/xtesting"
+	"github.com/PRPO-skupina-02/spored/db"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func orderedPromotions(db *gorm.DB) *gorm.DB {
+	return db.Order("promotions.name")
+}
+
+func TestPromotionsList(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name   string
+		status int
+		params string
+	}{
+		{
+			name:   "ok",
+			status: http.StatusOK,
+		},
+		{
+			name:   "ok-paginated",
+			status: http.StatusOK,
+			params: "?limit=2&offset=1",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/promotions%s", testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
+
+func TestPromotionsShow(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name        string
+		status      int
+		promotionID string
+	}{
+		{
+			name:        "ok",
+			status:      http.StatusOK,
+			promotionID: "2e8f4a6c-0b1d-4e3f-a5c7-9d1b3f5e7a03",
+		},
+		{
+			name:        "invalid-id",
+			status:      http.StatusNotFound,
+			promotionID: "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:        "malformed-id",
+			status:      http.StatusBadRequest,
+			promotionID: "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/promotions/%s", testCase.promotionID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
+
+func TestPromotionsCreate(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name            string
+		status          int
+		body            any
+		ignoreResp      xtesting.ValuesCheckers
+		ignorePromotion xtesting.ValuesCheckers
+	}{
+		{
+			name:   "ok",
+			status: http.StatusCreated,
+			body: PromotionRequest{
+				Name:          "Senior weekday matinee",
+				Weekdays:      []int{1, 2, 3, 4, 5},
+				StartBefore:   "16:00",
+				TheaterIDs:    []string{"fb126c8c-d059-11f0-8fa4-b35f33be83b7"},
+				TicketTypes:   []string{"SENIOR"},
+				ValidFrom:     "2026-01-01",
+				ValidUntil:    "2026-12-31",
+				DiscountType:  "AMOUNT",
+				DiscountValue: 300,
+			},
+			ignoreResp: xtesting.ValuesCheckers{
+				"id":         xtesting.ValueUUID(),
+				"created_at": xtesting.ValueTimeInPastDuration(time.Second),
+				"updated_at": xtesting.ValueTimeInPastDuration(time.Second),
+			},
+			ignorePromotion: xtesting.ValuesCheckers{
+				"[2].ID":        xtesting.ValueUUID(),
+				"[2].CreatedAt": xtesting.ValueTime(),
+				"[2].UpdatedAt": xtesting.ValueTime(),
+			},
+		},
+		{
+			name:   "percent-over-100",
+			status: http.StatusBadRequest,
+			body: PromotionRequest{
+				Name:          "Free tickets",
+				DiscountType:  "PERCENT",
+				DiscountValue: 150,
+			},
+		},
+		{
+			name:   "empty-start-window",
+			status: http.StatusBadRequest,
+			body: PromotionRequest{
+				Name:          "Evening discount",
+				StartAfter:    "20:00",
+				StartBefore:   "18:00",
+				DiscountType:  "AMOUNT",
+				DiscountValue: 100,
+			},
+		},
+		{
+			name:   "invalid-validity",
+			status: http.StatusBadRequest,
+			body: PromotionRequest{
+				Name:          "Backwards",
+				ValidFrom:     "2026-02-01",
+				ValidUntil:    "2026-01-01",
+				DiscountType:  "AMOUNT",
+				DiscountValue: 100,
+			},
+		},
+		{
+			name:   "invalid-movie-id",
+			status: http.StatusNotFound,
+			body: PromotionRequest{
+				Name:          "Unknown movie",
+				MovieIDs:      []string{"01234567-0123-0123-0123-0123456789ab"},
+				DiscountType:  "AMOUNT",
+				DiscountValue: 100,
+			},
+		},
+		{
+			name:   "validation-errors",
+			status: http.StatusBadRequest,
+			body: PromotionRequest{
+				Name:          "ab",
+				Weekdays:      []int{0, 8},
+				StartAfter:    "25:00",
+				MovieIDs:      []string{"000"},
+				TicketTypes:   []string{"BABY"},
+				ValidFrom:     "2026-13-01",
+				DiscountType:  "HALF",
+				DiscountValue: 0,
+			},
+		},
+		{
+			name:   "no-body",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := "/api/v1/spored/promotions"
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPost, testCase.body)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w, testCase.ignoreResp)
+			xtesting.AssertGoldenDatabaseTable(t, orderedPromotions(db), []models.Promotion{}, testCase.ignorePromotion)
+		})
+	}
+}
+
+func TestPromotionsUpdate(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name            string
+		status          int
+		promotionID     string
+		body            any
+		ignoreResp      xtesting.ValuesCheckers
+		ignorePromotion xtesting.ValuesCheckers
+	}{
+		{
+			name:        "ok",
+			status:      http.StatusOK,
+			promotionID: "2e8f4a6c-0b1d-4e3f-a5c7-9d1b3f5e7a02",
+			body: PromotionRequest{
+				Name:          "Student discount before 17:00",
+				StartBefore:   "17:00",
+				TicketTypes:   []string{"STUDENT"},
+				DiscountType:  "PERCENT",
+				DiscountValue: 25,
+			},
+			ignoreResp: xtesting.ValuesCheckers{
+				"updated_at": xtesting.ValueTimeInPastDuration(time.Second),
+			},
+			ignorePromotion: xtesting.ValuesCheckers{
+				"[1].UpdatedAt": xtesting.ValueTime(),
+			},
+		},
+		{
+			name:        "invalid-id",
+			status:      http.StatusNotFound,
+			promotionID: "01234567-0123-0123-0123-0123456789ab",
+			body: PromotionRequest{
+				Name:          "Nothing",
+				DiscountType:  "AMOUNT",
+				DiscountValue: 100,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/promotions/%s", testCase.promotionID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPut, testCase.body)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w, testCase.ignoreResp)
+			xtesting.AssertGoldenDatabaseTable(t, orderedPromotions(db), []models.Promotion{}, testCase.ignorePromotion)
+		})
+	}
+}
+
+func TestPromotionsDelete(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name        string
+		status      int
+		promotionID string
+	}{
+		{
+			name:        "ok",
+			status:      http.StatusNoContent,
+			promotionID: "2e8f4a6c-0b1d-4e3f-a5c7-9d1b3f5e7a04",
+		},
+		{
+			name:        "invalid-id",
+			status:      http.StatusNotFound,
+			promotionID: "01234567-0123-0123-0123-0123456789ab",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/promotions/%s", testCase.promotionID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodDelete, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+			xtesting.AssertGoldenDatabaseTable(t, orderedPromotions(db), []models.Promotion{}, nil)
+		})
+	}
+}
+
+func TestTimeSlotPricesEvaluate(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name       string
+		status     int
+		theaterID  string
+		roomID     string
+		timeSlotID string
+		params     string
+	}{
+		{
+			name:       "ok",
+			status:     http.StatusOK,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "7de8288d-964e-4d53-9d41-091e22ce8a6b",
+		},
+		{
+			name:       "ok-student",
+			status:     http.StatusOK,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "e843b5ce-c7ff-45e6-af57-94c88c9b8763",
+			params:     "?ticket_type=STUDENT",
+		},
+		{
+			name:       "ok-no-promotion",
+			status:     http.StatusOK,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "e843b5ce-c7ff-45e6-af57-94c88c9b8763",
+			params:     "?ticket_type=ADULT",
+		},
+		{
+			name:       "invalid-ticket-type",
+			status:     http.StatusBadRequest,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "e843b5ce-c7ff-45e6-af57-94c88c9b8763",
+			params:     "?ticket_type=BABY",
+		},
+		{
+			name:       "no-prices",
+			status:     http.StatusNotFound,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+		},
+		{
+			name:       "invalid-timeslot-id",
+			status:     http.StatusNotFound,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "01234567-0123-0123-0123-0123456789ab",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s/timeslots/%s/prices%s", testCase.theaterID, testCase.roomID, testCase.timeSlotID, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/PRPO-skupina-02/common/middleware"
@@ -18,9 +20,15 @@ type RoomResponse struct {
 	Name          string                   `json:"name"`
 	Rows          int                      `json:"rows"`
 	Columns       int                      `json:"columns"`
+	Capacity      int                      `json:"capacity"`
+	ThreeD        bool                     `json:"three_d"`
+	IMAX          bool                     `json:"imax"`
 	OperatingMode models.RoomOperatingMode `json:"operating_mode"`
 	OpeningHour   int                      `json:"opening_hour"`
 	ClosingHour   int                      `json:"closing_hour"`
+	ScheduleMode  models.RoomScheduleMode  `json:"schedule_mode"`
+
+	Theater *TheaterResponse `json:"theater,omitempty"`
 }
 
 func newRoomResponse(room models.Room) RoomResponse {
@@ -31,10 +39,25 @@ func newRoomResponse(room models.Room) RoomResponse {
 		Name:          room.Name,
 		Rows:          room.Rows,
 		Columns:       room.Columns,
+		Capacity:      room.Capacity,
+		ThreeD:        room.ThreeD,
+		IMAX:          room.IMAX,
 		OperatingMode: room.OperatingMode,
 		OpeningHour:   room.OpeningHour,
 		ClosingHour:   room.ClosingHour,
+		ScheduleMode:  room.ScheduleMode,
+	}
+}
+
+func newExpandedRoomResponse(room models.Room, expand expandOptions) RoomResponse {
+	response := newRoomResponse(room)
+
+	if expand["theater"] {
+		theater := newTheaterResponse(room.Theater)
+		response.Theater = &theater
 	}
+
+	return response
 }
 
 // RoomsList
@@ -49,6 +72,7 @@ func newRoomResponse(room models.Room) RoomResponse {
 //	@Param			limit		query		int		false	"Limit the number of responses"	Default(10)
 //	@Param			offset		query		int		false	"Offset the first response"		Default(0)
 //	@Param			sort		query		string	false	"Sort results"
+//	@Param			expand		query		string	false	"Comma separated related resources to expand (theater)"
 //	@Success		200			{object}	request.PaginatedResponse{data=[]RoomResponse}
 //	@Failure		400			{object}	middleware.HttpError
 //	@Failure		404			{object}	middleware.HttpError
@@ -60,7 +84,13 @@ func RoomsList(c *gin.Context) {
 	pagination := request.GetNormalizedPaginationArgs(c)
 	sort := request.GetSortOptions(c)
 
-	rooms, total, err := models.GetTheaterRooms(tx, theater.ID, pagination, sort)
+	expand, preloads, err := getExpandOptions(c, roomExpandPreloads)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	rooms, total, err := models.GetTheaterRooms(tx, theater.ID, pagination, sort, preloads...)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -69,7 +99,7 @@ func RoomsList(c *gin.Context) {
 	response := []RoomResponse{}
 
 	for _, room := range rooms {
-		response = append(response, newRoomResponse(room))
+		response = append(response, newExpandedRoomResponse(room, expand))
 	}
 
 	request.RenderPaginatedResponse(c, response, total)
@@ -82,6 +112,27 @@ type RoomRequest struct {
 	OperatingMode string `json:"operating_mode" binding:"required,oneof=CLOSED WEEKDAYS WEEKENDS ALL" enums:"CLOSED,WEEKDAYS,WEEKENDS,ALL"`
 	OpeningHour   int    `json:"opening_hour" binding:"required,min=0,max=24"`
 	ClosingHour   int    `json:"closing_hour" binding:"required,min=0,max=24"`
+	ScheduleMode  string `json:"schedule_mode" binding:"omitempty,oneof=AUTOMATIC TEMPLATE" enums:"AUTOMATIC,TEMPLATE"`
+	// Whether the room screens in 3D, the current value is kept when omitted
+	ThreeD *bool `json:"three_d"`
+	// Whether the room is an IMAX room, the current value is kept when omitted
+	IMAX *bool `json:"imax"`
+}
+
+// getForce reads the force query parameter, which allows changes that cancel
+// reserved time slots
+func getForce(c *gin.Context) (bool, error) {
+	query := c.Query("force")
+	if query == "" {
+		return false, nil
+	}
+
+	force, err := strconv.ParseBool(query)
+	if err != nil {
+		return false, middleware.NewBadRequestError(fmt.Sprintf("Invalid force: %s", query))
+	}
+
+	return force, nil
 }
 
 // RoomsCreate
@@ -119,6 +170,17 @@ func RoomsCreate(c *gin.Context) {
 		OperatingMode: models.RoomOperatingMode(req.OperatingMode),
 		OpeningHour:   req.OpeningHour,
 		ClosingHour:   req.ClosingHour,
+		ScheduleMode:  models.AutomaticSchedule,
+	}
+
+	if req.ScheduleMode != "" {
+		room.ScheduleMode = models.RoomScheduleMode(req.ScheduleMode)
+	}
+	if req.ThreeD != nil {
+		room.ThreeD = *req.ThreeD
+	}
+	if req.IMAX != nil {
+		room.IMAX = *req.IMAX
 	}
 
 	err = room.Create(tx)
@@ -127,6 +189,12 @@ func RoomsCreate(c *gin.Context) {
 		return
 	}
 
+	err = room.ResizeSeats(tx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	c.JSON(http.StatusCreated, newRoomResponse(room))
 }
 
@@ -140,6 +208,7 @@ func RoomsCreate(c *gin.Context) {
 //	@Produce		json
 //	@Param			theaterID	path		string	true	"Theater ID"	Format(uuid)
 //	@Param			roomID		path		string	true	"Room ID"		Format(uuid)
+//	@Param			expand		query		string	false	"Comma separated related resources to expand (theater)"
 //	@Success		200			{object}	RoomResponse
 //	@Failure		400			{object}	middleware.HttpError
 //	@Failure		404			{object}	middleware.HttpError
@@ -154,29 +223,37 @@ func RoomsShow(c *gin.Context) {
 		return
 	}
 
-	room, err := models.GetRoom(tx, theater.ID, id)
+	expand, preloads, err := getExpandOptions(c, roomExpandPreloads)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, newRoomResponse(room))
+	room, err := models.GetRoom(tx, theater.ID, id, preloads...)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newExpandedRoomResponse(room, expand))
 }
 
 // RoomsUpdate
 //
 //	@Id				RoomsUpdate
 //	@Summary		Update room
-//	@Description	Update room
+//	@Description	Update room. Shrinking the room is refused with a conflict listing the future time slots that have reservations for the removed seats, unless forced, which cancels those time slots. Removed seats with confirmed reservations are disabled and kept for the reservation history
 //	@Tags			rooms
 //	@Accept			json
 //	@Produce		json
 //	@Param			theaterID	path		string		true	"Theater ID"	Format(uuid)
 //	@Param			roomID		path		string		true	"Room ID"		Format(uuid)
+//	@Param			force		query		bool		false	"Cancel time slots with reservations for removed seats"
 //	@Param			request		body		RoomRequest	true	"request body"
 //	@Success		200			{object}	RoomResponse
 //	@Failure		400			{object}	middleware.HttpError
 //	@Failure		404			{object}	middleware.HttpError
+//	@Failure		409			{object}	middleware.HttpError
 //	@Failure		500			{object}	middleware.HttpError
 //	@Router			/theaters/{theaterID}/rooms/{roomID} [put]
 func RoomsUpdate(c *gin.Context) {
@@ -188,6 +265,12 @@ func RoomsUpdate(c *gin.Context) {
 		return
 	}
 
+	force, err := getForce(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	var req RoomRequest
 	err = c.ShouldBindJSON(&req)
 	if err != nil {
@@ -201,6 +284,12 @@ func RoomsUpdate(c *gin.Context) {
 		return
 	}
 
+	err = room.PrepareResize(tx, req.Rows, req.Columns, force, time.Now())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	room.Name = req.Name
 	room.Rows = req.Rows
 	room.Columns = req.Columns
@@ -208,12 +297,28 @@ func RoomsUpdate(c *gin.Context) {
 	room.OpeningHour = req.OpeningHour
 	room.ClosingHour = req.ClosingHour
 
+	if req.ScheduleMode != "" {
+		room.ScheduleMode = models.RoomScheduleMode(req.ScheduleMode)
+	}
+	if req.ThreeD != nil {
+		room.ThreeD = *req.ThreeD
+	}
+	if req.IMAX != nil {
+		room.IMAX = *req.IMAX
+	}
+
 	err = room.Save(tx)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
+	err = room.ResizeSeats(tx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	c.JSON(http.StatusOK, newRoomResponse(room))
 }
 
@@ -221,15 +326,17 @@ func RoomsUpdate(c *gin.Context) {
 //
 //	@Id				RoomsDelete
 //	@Summary		Delete room
-//	@Description	Delete room
+//	@Description	Delete room and cancel its future time slots. Rooms with reservations for future time slots are not deleted and the reserved time slots are listed in a conflict, unless forced
 //	@Tags			rooms
 //	@Accept			json
 //	@Produce		json
 //	@Param			theaterID	path	string	true	"Theater ID"	Format(uuid)
 //	@Param			roomID		path	string	true	"Room ID"		Format(uuid)
+//	@Param			force		query	bool	false	"Delete the room even if future time slots have reservations"
 //	@Success		204
 //	@Failure		400	{object}	middleware.HttpError
 //	@Failure		404	{object}	middleware.HttpError
+//	@Failure		409	{object}	middleware.HttpError
 //	@Failure		500	{object}	middleware.HttpError
 //	@Router			/theaters/{theaterID}/rooms/{roomID} [delete]
 func RoomsDelete(c *gin.Context) {
@@ -241,7 +348,13 @@ func RoomsDelete(c *gin.Context) {
 		return
 	}
 
-	err = models.DeleteRoom(tx, theater.ID, id)
+	force, err := getForce(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = models.DeleteRoom(tx, theater.ID, id, force)
 	if err != nil {
 		_ = c.Error(err)
 		return
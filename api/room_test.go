@@ -47,6 +47,18 @@ func TestRoomsList(t *testing.T) {
 			params:    "?limit=2&offset=1&sort=updated_at",
 			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
 		},
+		{
+			name:      "ok-expand",
+			status:    http.StatusOK,
+			params:    "?expand=theater",
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+		},
+		{
+			name:      "invalid-expand",
+			status:    http.StatusBadRequest,
+			params:    "?expand=movie",
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+		},
 		{
 			name:      "ok-no-rooms",
 			status:    http.StatusOK,
@@ -278,6 +290,7 @@ func TestRoomsShow(t *testing.T) {
 func TestRoomsUpdate(t *testing.T) {
 	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
 	r := TestingRouter(t, db)
+	enabled, disabled := true, false
 
 	tests := []struct {
 		name      string
@@ -300,6 +313,22 @@ func TestRoomsUpdate(t *testing.T) {
 			roomID:    "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
 			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
 		},
+		{
+			name: "ok-features",
+			body: RoomRequest{
+				Name:          "Theater2 Room1",
+				Rows:          20,
+				Columns:       10,
+				OperatingMode: string(models.All),
+				OpeningHour:   18,
+				ClosingHour:   24,
+				ThreeD:        &enabled,
+				IMAX:          &disabled,
+			},
+			status:    http.StatusOK,
+			roomID:    "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+		},
 		{
 			name: "validation-errors",
 			body: RoomRequest{
@@ -446,15 +475,94 @@ func TestRoomsUpdate(t *testing.T) {
 	}
 }
 
+func TestRoomsUpdateReservedSeats(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name            string
+		status          int
+		params          string
+		rows            int
+		columns         int
+		ignoreTimeSlots xtesting.ValuesCheckers
+	}{
+		{
+			name:    "ok-unreserved-seats",
+			status:  http.StatusOK,
+			rows:    10,
+			columns: 5,
+		},
+		{
+			name:    "reserved-seats",
+			status:  http.StatusConflict,
+			rows:    20,
+			columns: 1,
+		},
+		{
+			name:            "ok-force",
+			status:          http.StatusOK,
+			params:          "?force=true",
+			rows:            20,
+			columns:         1,
+			ignoreTimeSlots: xtesting.ValuesCheckers{"[32].UpdatedAt": xtesting.ValueTime(), "[32].CancelledAt": xtesting.ValueTime()},
+		},
+		{
+			name:    "invalid-force",
+			status:  http.StatusBadRequest,
+			params:  "?force=maybe",
+			rows:    20,
+			columns: 1,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/bae209f6-d059-11f0-b2a4-cbf992c2eb6d/rooms/e0722c3a-df42-11f0-9579-3734395be62a%s", testCase.params)
+
+			body := RoomRequest{
+				Name:          "Theater1 Room2",
+				Rows:          testCase.rows,
+				Columns:       testCase.columns,
+				OperatingMode: string(models.Weekends),
+				OpeningHour:   8,
+				ClosingHour:   22,
+			}
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPut, body)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			ignoreResp := xtesting.ValuesCheckers{
+				"updated_at": xtesting.ValueTimeInPastDuration(time.Second),
+			}
+
+			ignoreRooms := xtesting.GenerateValueCheckersForArrays(map[string]xtesting.ValueChecker{"UpdatedAt": xtesting.ValueTime()}, 10)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w, ignoreResp)
+			xtesting.AssertGoldenDatabaseTable(t, db.Order("id"), []models.Room{}, ignoreRooms)
+			xtesting.AssertGoldenDatabaseTable(t, db.Where("room_id = ?", "e0722c3a-df42-11f0-9579-3734395be62a").Order("start_time"), []models.TimeSlot{}, testCase.ignoreTimeSlots)
+			xtesting.AssertGoldenDatabaseTable(t, orderedSeatReservations(db), []models.SeatReservation{}, nil)
+		})
+	}
+}
+
 func TestRoomsDelete(t *testing.T) {
 	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
 	r := TestingRouter(t, db)
 
 	tests := []struct {
-		name      string
-		status    int
-		roomID    string
-		theaterID string
+		name            string
+		status          int
+		roomID          string
+		theaterID       string
+		params          string
+		ignoreTimeSlots xtesting.ValuesCheckers
 	}{
 		{
 			name:      "ok",
@@ -462,6 +570,27 @@ func TestRoomsDelete(t *testing.T) {
 			roomID:    "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
 			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
 		},
+		{
+			name:            "ok-cancel-future-timeslots",
+			status:          http.StatusNoContent,
+			roomID:          "e0722c3a-df42-11f0-9579-3734395be62a",
+			theaterID:       "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			params:          "?force=true",
+			ignoreTimeSlots: xtesting.GenerateValueCheckersForArraysWithOffset(map[string]xtesting.ValueChecker{"UpdatedAt": xtesting.ValueTime(), "CancelledAt": xtesting.ValueTime()}, 4, 32),
+		},
+		{
+			name:      "reserved-timeslots",
+			status:    http.StatusConflict,
+			roomID:    "e0722c3a-df42-11f0-9579-3734395be62a",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+		},
+		{
+			name:      "invalid-force",
+			status:    http.StatusBadRequest,
+			roomID:    "e0722c3a-df42-11f0-9579-3734395be62a",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			params:    "?force=maybe",
+		},
 		{
 			name:      "room-from-different-theater",
 			status:    http.StatusNotFound,
@@ -511,7 +640,7 @@ func TestRoomsDelete(t *testing.T) {
 			err := fixtures.Load()
 			assert.NoError(t, err)
 
-			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s", testCase.theaterID, testCase.roomID)
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s%s", testCase.theaterID, testCase.roomID, testCase.params)
 
 			req := xtesting.NewTestingRequest(t, targetURL, http.MethodDelete, nil)
 			w := httptest.NewRecorder()
@@ -521,6 +650,7 @@ func TestRoomsDelete(t *testing.T) {
 			assert.Equal(t, testCase.status, w.Code)
 			xtesting.AssertGoldenJSON(t, w)
 			xtesting.AssertGoldenDatabaseTable(t, db, []models.Room{}, nil)
+			xtesting.AssertGoldenDatabaseTable(t, db.Where("room_id = ?", "e0722c3a-df42-11f0-9579-3734395be62a").Order("start_time"), []models.TimeSlot{}, testCase.ignoreTimeSlots)
 		})
 	}
 }
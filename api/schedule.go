@@ -0,0 +1,376 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/PRPO-skupina-02/common/request"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/PRPO-skupina-02/spored/spored"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type MovieDemandResponse struct {
+	MovieID    uuid.UUID `json:"movie_id"`
+	Screenings int       `json:"screenings"`
+	Occupancy  float64   `json:"occupancy"`
+	Weight     float64   `json:"weight"`
+}
+
+func newMovieDemandResponses(demands []models.MovieDemand) []MovieDemandResponse {
+	response := []MovieDemandResponse{}
+	for _, demand := range demands {
+		response = append(response, MovieDemandResponse{
+			MovieID:    demand.MovieID,
+			Screenings: demand.Screenings,
+			Occupancy:  demand.Occupancy,
+			Weight:     demand.Weight,
+		})
+	}
+	return response
+}
+
+type ScheduleRunRoomResponse struct {
+	RoomID   uuid.UUID `json:"room_id"`
+	Capacity int       `json:"capacity"`
+	// The room was populated with the weights of the run raised to this
+	// exponent, twice the share of its capacity in the largest capacity of the
+	// theater
+	Exponent float64 `json:"exponent"`
+}
+
+type ScheduleRunResponse struct {
+	ID        uuid.UUID                 `json:"id"`
+	CreatedAt time.Time                 `json:"created_at"`
+	Kind      models.ScheduleRunKind    `json:"kind"`
+	TheaterID uuid.UUID                 `json:"theater_id"`
+	Demands   []MovieDemandResponse     `json:"demands"`
+	Rooms     []ScheduleRunRoomResponse `json:"rooms"`
+}
+
+func newScheduleRunResponse(run models.ScheduleRun) ScheduleRunResponse {
+	response := ScheduleRunResponse{
+		ID:        run.ID,
+		CreatedAt: run.CreatedAt,
+		Kind:      run.Kind,
+		TheaterID: run.TheaterID,
+		Demands:   newMovieDemandResponses(run.Demands),
+		Rooms:     []ScheduleRunRoomResponse{},
+	}
+
+	for _, room := range run.Rooms {
+		response.Rooms = append(response.Rooms, ScheduleRunRoomResponse{
+			RoomID:   room.RoomID,
+			Capacity: room.Capacity,
+			Exponent: room.Exponent,
+		})
+	}
+
+	return response
+}
+
+// SchedulePopulate
+//
+//	@Id				SchedulePopulate
+//	@Summary		Repopulate theater schedule
+//	@Description	Fill every gap in the theater schedule up to its horizon, instead of only extending newly uncovered days. Movies are picked with weights based on their recent occupancy, the run with the weights used is listed with the schedule runs of the theater.
+//	@Tags			schedule
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path	string	true	"Theater ID"	Format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	middleware.HttpError
+//	@Failure		404	{object}	middleware.HttpError
+//	@Failure		500	{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/schedule/populate [post]
+func SchedulePopulate(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	theater := GetContextTheater(c)
+
+	err := spored.PopulateTheater(tx, theater)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, "")
+}
+
+// ScheduleRunsList
+//
+//	@Id				ScheduleRunsList
+//	@Summary		List schedule runs
+//	@Description	List the runs that populated or extended the theater schedule, newest first, with the movie weights each run was based on
+//	@Tags			schedule
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string	true	"Theater ID"					Format(uuid)
+//	@Param			limit		query		int		false	"Limit the number of responses"	Default(10)
+//	@Param			offset		query		int		false	"Offset the first response"		Default(0)
+//	@Param			sort		query		string	false	"Sort results"
+//	@Success		200			{object}	request.PaginatedResponse{data=[]ScheduleRunResponse}
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/schedule/runs [get]
+func ScheduleRunsList(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	theater := GetContextTheater(c)
+	pagination := request.GetNormalizedPaginationArgs(c)
+	sort := request.GetSortOptions(c)
+
+	runs, total, err := models.GetTheaterScheduleRuns(tx, theater.ID, pagination, sort)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := []ScheduleRunResponse{}
+
+	for _, run := range runs {
+		response = append(response, newScheduleRunResponse(run))
+	}
+
+	request.RenderPaginatedResponse(c, response, total)
+}
+
+// ScheduleRunsShow
+//
+//	@Id				ScheduleRunsShow
+//	@Summary		Show schedule run
+//	@Description	Show a run of the theater schedule with the movie weights it was based on
+//	@Tags			schedule
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string	true	"Theater ID"	Format(uuid)
+//	@Param			runID		path		string	true	"Run ID"		Format(uuid)
+//	@Success		200			{object}	ScheduleRunResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/schedule/runs/{runID} [get]
+func ScheduleRunsShow(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	theater := GetContextTheater(c)
+
+	id, err := request.GetUUIDParam(c, "runID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	run, err := models.GetScheduleRun(tx, theater.ID, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newScheduleRunResponse(run))
+}
+
+// ScheduleDemand
+//
+//	@Id				ScheduleDemand
+//	@Summary		Show movie demand
+//	@Description	Show the movie weights the next run of the theater schedule would use, based on the decayed occupancy of the screenings of the last four weeks. Movies without screenings are not listed and weigh 1.
+//	@Tags			schedule
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string	true	"Theater ID"										Format(uuid)
+//	@Param			at			query		string	false	"Instant to compute the demand at, now by default"	Format(date-time)
+//	@Success		200			{array}		MovieDemandResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/schedule/demand [get]
+func ScheduleDemand(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	theater := GetContextTheater(c)
+
+	instant, err := getNowInstant(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	demands, err := models.GetMovieDemands(tx, theater.ID, instant)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newMovieDemandResponses(demands))
+}
+
+// Schedules can be copied from at most this many days at once
+const maxCopyDays = 31
+
+type ScheduleCopyRequest struct {
+	SourceFrom string   `json:"source_from" binding:"required,datetime=2006-01-02" format:"date"`
+	SourceTo   string   `json:"source_to" binding:"omitempty,datetime=2006-01-02" format:"date"`
+	TargetFrom string   `json:"target_from" binding:"required,datetime=2006-01-02" format:"date"`
+	RoomIDs    []string `json:"room_ids" binding:"omitempty,dive,uuid,non-nil-uuid"`
+	DryRun     bool     `json:"dry_run" binding:"boolean"`
+}
+
+type ScheduleCopySkipResponse struct {
+	Source TimeSlotResponse `json:"source"`
+	Reason string           `json:"reason"`
+}
+
+type ScheduleCopyConflictResponse struct {
+	Source   TimeSlotResponse `json:"source"`
+	Existing TimeSlotResponse `json:"existing"`
+}
+
+type ScheduleCopyResponse struct {
+	DryRun    bool                           `json:"dry_run"`
+	Created   []TimeSlotResponse             `json:"created"`
+	Skipped   []ScheduleCopySkipResponse     `json:"skipped"`
+	Conflicts []ScheduleCopyConflictResponse `json:"conflicts"`
+}
+
+func newScheduleCopyResponse(result models.TimeSlotCopyResult, dryRun bool) ScheduleCopyResponse {
+	response := ScheduleCopyResponse{
+		DryRun:    dryRun,
+		Created:   []TimeSlotResponse{},
+		Skipped:   []ScheduleCopySkipResponse{},
+		Conflicts: []ScheduleCopyConflictResponse{},
+	}
+
+	for _, timeSlot := range result.Created {
+		response.Created = append(response.Created, newTimeSlotResponse(timeSlot))
+	}
+
+	for _, skip := range result.Skipped {
+		response.Skipped = append(response.Skipped, ScheduleCopySkipResponse{
+			Source: newTimeSlotResponse(skip.Source),
+			Reason: skip.Reason,
+		})
+	}
+
+	for _, conflict := range result.Conflicts {
+		response.Conflicts = append(response.Conflicts, ScheduleCopyConflictResponse{
+			Source:   newTimeSlotResponse(conflict.Source),
+			Existing: newTimeSlotResponse(conflict.Existing),
+		})
+	}
+
+	return response
+}
+
+// ScheduleCopy
+//
+//	@Id				ScheduleCopy
+//	@Summary		Copy theater schedule
+//	@Description	Copy the time slots between source_from and source_to (inclusive, a week by default and at most 31 days) so that they start on target_from, which can not be before today or overlap the source range. Slots of inactive movies, movies outside of their release window or outside of the operating days and hours of the room are skipped, and slots overlapping existing ones are reported as conflicts. With dry_run nothing is saved.
+//	@Tags			schedule
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string				true	"Theater ID"	Format(uuid)
+//	@Param			request		body		ScheduleCopyRequest	true	"request body"
+//	@Success		200			{object}	ScheduleCopyResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/schedule/copy [post]
+func ScheduleCopy(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	theater := GetContextTheater(c)
+
+	var req ScheduleCopyRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	sourceFrom, err := time.Parse(time.DateOnly, req.SourceFrom)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	sourceTo := sourceFrom.AddDate(0, 0, 6)
+	if req.SourceTo != "" {
+		sourceTo, err = time.Parse(time.DateOnly, req.SourceTo)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+
+	if sourceTo.Before(sourceFrom) {
+		_ = c.Error(middleware.NewBadRequestError("Source end date must not be before source start date"))
+		return
+	}
+
+	if sourceTo.After(sourceFrom.AddDate(0, 0, maxCopyDays-1)) {
+		_ = c.Error(middleware.NewBadRequestError(fmt.Sprintf("Source range must not be longer than %d days", maxCopyDays)))
+		return
+	}
+
+	targetFrom, err := time.Parse(time.DateOnly, req.TargetFrom)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if targetFrom.Before(time.Now().UTC().Truncate(24 * time.Hour)) {
+		_ = c.Error(middleware.NewBadRequestError("Target start date must not be before today"))
+		return
+	}
+
+	// The target range is as long as the source range, so they overlap when
+	// the target starts less than that many days away from the source
+	days := sourceTo.Sub(sourceFrom) + 24*time.Hour
+	if shift := targetFrom.Sub(sourceFrom); shift > -days && shift < days {
+		_ = c.Error(middleware.NewBadRequestError("Target range must not overlap the source range"))
+		return
+	}
+
+	var rooms []models.Room
+	if len(req.RoomIDs) == 0 {
+		rooms, _, err = models.GetTheaterRooms(tx, theater.ID, nil, nil, models.PreloadOrderedTimeSlotsScope)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+	} else {
+		copied := map[uuid.UUID]bool{}
+		for _, roomID := range req.RoomIDs {
+			id := uuid.MustParse(roomID)
+			if copied[id] {
+				continue
+			}
+			copied[id] = true
+
+			room, err := models.GetRoom(tx, theater.ID, id, models.PreloadOrderedTimeSlotsScope)
+			if err != nil {
+				_ = c.Error(err)
+				return
+			}
+			rooms = append(rooms, room)
+		}
+	}
+
+	movies, _, err := models.GetMovies(tx, nil, nil)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	result := models.TimeSlotCopyResult{}
+	for _, room := range rooms {
+		err := room.CopyTimeSlots(tx, sourceFrom, sourceTo.AddDate(0, 0, 1), targetFrom.Sub(sourceFrom), movies, req.DryRun, &result)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, newScheduleCopyResponse(result, req.DryRun))
+}
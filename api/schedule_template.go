@@ -0,0 +1,269 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/PRPO-skupina-02/common/request"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ScheduleTemplateResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Weekday   int        `json:"weekday"`
+	StartTime string     `json:"start_time"`
+	RoomID    uuid.UUID  `json:"room_id"`
+	MovieID   *uuid.UUID `json:"movie_id"`
+}
+
+func newScheduleTemplateResponse(template models.ScheduleTemplate) ScheduleTemplateResponse {
+	return ScheduleTemplateResponse{
+		ID:        template.ID,
+		CreatedAt: template.CreatedAt,
+		UpdatedAt: template.UpdatedAt,
+		Weekday:   template.Weekday,
+		StartTime: fmt.Sprintf("%02d:%02d", template.StartHour, template.StartMinute),
+		RoomID:    template.RoomID,
+		MovieID:   template.MovieID,
+	}
+}
+
+// ScheduleTemplatesList
+//
+//	@Id				ScheduleTemplatesList
+//	@Summary		List schedule templates
+//	@Description	List weekly schedule templates of a room
+//	@Tags			templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string	true	"Theater ID"					Format(uuid)
+//	@Param			roomID		path		string	true	"Room ID"						Format(uuid)
+//	@Param			limit		query		int		false	"Limit the number of responses"	Default(10)
+//	@Param			offset		query		int		false	"Offset the first response"		Default(0)
+//	@Param			sort		query		string	false	"Sort results"
+//	@Success		200			{object}	request.PaginatedResponse{data=[]ScheduleTemplateResponse}
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/templates [get]
+func ScheduleTemplatesList(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	pagination := request.GetNormalizedPaginationArgs(c)
+	sort := request.GetSortOptions(c)
+
+	templates, total, err := models.GetRoomScheduleTemplates(tx, room.ID, pagination, sort)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := []ScheduleTemplateResponse{}
+
+	for _, template := range templates {
+		response = append(response, newScheduleTemplateResponse(template))
+	}
+
+	request.RenderPaginatedResponse(c, response, total)
+}
+
+type ScheduleTemplateRequest struct {
+	Weekday   int    `json:"weekday" binding:"required,min=1,max=7"`
+	StartTime string `json:"start_time" binding:"required,datetime=15:04"`
+	MovieID   string `json:"movie_id" binding:"omitempty,uuid,non-nil-uuid"`
+}
+
+func applyScheduleTemplateRequest(c *gin.Context, room models.Room, template *models.ScheduleTemplate) error {
+	tx := middleware.GetContextTransaction(c)
+
+	var req ScheduleTemplateRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		return err
+	}
+
+	startTime, err := time.Parse("15:04", req.StartTime)
+	if err != nil {
+		return err
+	}
+
+	if startTime.Hour() < room.OpeningHour || startTime.Hour() >= room.ClosingHour {
+		return middleware.NewBadRequestError("Template start time is outside of room operating hours")
+	}
+
+	template.MovieID = nil
+	if req.MovieID != "" {
+		movie, err := models.GetMovie(tx, uuid.MustParse(req.MovieID))
+		if err != nil {
+			return err
+		}
+		template.MovieID = &movie.ID
+	}
+
+	template.Weekday = req.Weekday
+	template.StartHour = startTime.Hour()
+	template.StartMinute = startTime.Minute()
+
+	return nil
+}
+
+// ScheduleTemplatesCreate
+//
+//	@Id				ScheduleTemplatesCreate
+//	@Summary		Create schedule template
+//	@Description	Create a weekly schedule template for a room. Templates without a movie are filled with an automatically selected movie.
+//	@Tags			templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string					true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path		string					true	"Room ID"		Format(uuid)
+//	@Param			request		body		ScheduleTemplateRequest	true	"request body"
+//	@Success		200			{object}	ScheduleTemplateResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/templates [post]
+func ScheduleTemplatesCreate(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+
+	template := models.ScheduleTemplate{
+		ID:     uuid.New(),
+		RoomID: room.ID,
+	}
+
+	err := applyScheduleTemplateRequest(c, room, &template)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = template.Create(tx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newScheduleTemplateResponse(template))
+}
+
+// ScheduleTemplatesShow
+//
+//	@Id				ScheduleTemplatesShow
+//	@Summary		Show schedule template
+//	@Description	Show schedule template
+//	@Tags			templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string	true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path		string	true	"Room ID"		Format(uuid)
+//	@Param			templateID	path		string	true	"Template ID"	Format(uuid)
+//	@Success		200			{object}	ScheduleTemplateResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/templates/{templateID} [get]
+func ScheduleTemplatesShow(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	id, err := request.GetUUIDParam(c, "templateID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	template, err := models.GetScheduleTemplate(tx, room.ID, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newScheduleTemplateResponse(template))
+}
+
+// ScheduleTemplatesUpdate
+//
+//	@Id				ScheduleTemplatesUpdate
+//	@Summary		Update schedule template
+//	@Description	Update schedule template
+//	@Tags			templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string					true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path		string					true	"Room ID"		Format(uuid)
+//	@Param			templateID	path		string					true	"Template ID"	Format(uuid)
+//	@Param			request		body		ScheduleTemplateRequest	true	"request body"
+//	@Success		200			{object}	ScheduleTemplateResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/templates/{templateID} [put]
+func ScheduleTemplatesUpdate(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	id, err := request.GetUUIDParam(c, "templateID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	template, err := models.GetScheduleTemplate(tx, room.ID, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = applyScheduleTemplateRequest(c, room, &template)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = template.Save(tx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newScheduleTemplateResponse(template))
+}
+
+// ScheduleTemplatesDelete
+//
+//	@Id				ScheduleTemplatesDelete
+//	@Summary		Delete schedule template
+//	@Description	Delete schedule template
+//	@Tags			templates
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path	string	true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path	string	true	"Room ID"		Format(uuid)
+//	@Param			templateID	path	string	true	"Template ID"	Format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	middleware.HttpError
+//	@Failure		404	{object}	middleware.HttpError
+//	@Failure		500	{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/templates/{templateID} [delete]
+func ScheduleTemplatesDelete(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	id, err := request.GetUUIDParam(c, "templateID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = models.DeleteScheduleTemplate(tx, room.ID, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, "")
+}
@@ -0,0 +1,390 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/database"
+	"github.com/PRPO-skupina-02/common/xtesting"
+	"github.com/PRPO-skupina-02/spored/db"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleTemplatesList(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name      string
+		status    int
+		params    string
+		theaterID string
+		roomID    string
+	}{
+		{
+			name:      "ok",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name:      "ok-paginated",
+			status:    http.StatusOK,
+			params:    "?limit=1&offset=1",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name:      "ok-no-templates",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "e0722c3a-df42-11f0-9579-3734395be62a",
+		},
+		{
+			name:      "room-from-different-theater",
+			status:    http.StatusNotFound,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name:      "invalid-room-id",
+			status:    http.StatusNotFound,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:      "nil-room-id",
+			status:    http.StatusBadRequest,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			name:      "malformed-room-id",
+			status:    http.StatusBadRequest,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s/templates%s", testCase.theaterID, testCase.roomID, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
+
+func TestScheduleTemplatesCreate(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name      string
+		body      ScheduleTemplateRequest
+		status    int
+		theaterID string
+		roomID    string
+	}{
+		{
+			name: "ok",
+			body: ScheduleTemplateRequest{
+				Weekday:   3,
+				StartTime: "14:30",
+				MovieID:   "27e36818-e240-11f0-bb29-538173c01e43",
+			},
+			status:    http.StatusCreated,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name: "ok-automatic-movie",
+			body: ScheduleTemplateRequest{
+				Weekday:   7,
+				StartTime: "22:00",
+			},
+			status:    http.StatusCreated,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name: "validation-errors",
+			body: ScheduleTemplateRequest{
+				Weekday:   9,
+				StartTime: "25:99",
+				MovieID:   "abc",
+			},
+			status:    http.StatusBadRequest,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name:      "no-body",
+			status:    http.StatusBadRequest,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name: "outside-operating-hours",
+			body: ScheduleTemplateRequest{
+				Weekday:   3,
+				StartTime: "09:00",
+			},
+			status:    http.StatusBadRequest,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name: "invalid-movie-id",
+			body: ScheduleTemplateRequest{
+				Weekday:   3,
+				StartTime: "14:30",
+				MovieID:   "01234567-0123-0123-0123-0123456789ab",
+			},
+			status:    http.StatusNotFound,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name: "invalid-room-id",
+			body: ScheduleTemplateRequest{
+				Weekday:   3,
+				StartTime: "14:30",
+			},
+			status:    http.StatusNotFound,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "01234567-0123-0123-0123-0123456789ab",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s/templates", testCase.theaterID, testCase.roomID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPost, testCase.body)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			ignoreResp := xtesting.ValuesCheckers{
+				"id":         xtesting.ValueUUID(),
+				"created_at": xtesting.ValueTimeInPastDuration(time.Second),
+				"updated_at": xtesting.ValueTimeInPastDuration(time.Second),
+			}
+
+			ignoreTemplates := xtesting.GenerateValueCheckersForArrays(map[string]xtesting.ValueChecker{"ID": xtesting.ValueUUID(), "CreatedAt": xtesting.ValueTime(), "UpdatedAt": xtesting.ValueTime()}, 10)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w, ignoreResp)
+			xtesting.AssertGoldenDatabaseTable(t, db.Order("weekday, start_hour"), []models.ScheduleTemplate{}, ignoreTemplates)
+		})
+	}
+}
+
+func TestScheduleTemplatesShow(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name       string
+		status     int
+		theaterID  string
+		roomID     string
+		templateID string
+	}{
+		{
+			name:       "ok",
+			status:     http.StatusOK,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			templateID: "4a3d1657-c9f0-485f-ae4b-1cb81703eb8d",
+		},
+		{
+			name:       "template-from-different-room",
+			status:     http.StatusNotFound,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			templateID: "29285455-ed58-4553-9bf8-d2acf8e7af81",
+		},
+		{
+			name:       "invalid-template-id",
+			status:     http.StatusNotFound,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			templateID: "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:       "nil-template-id",
+			status:     http.StatusBadRequest,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			templateID: "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			name:       "malformed-template-id",
+			status:     http.StatusBadRequest,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			templateID: "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s/templates/%s", testCase.theaterID, testCase.roomID, testCase.templateID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
+
+func TestScheduleTemplatesUpdate(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name       string
+		body       ScheduleTemplateRequest
+		status     int
+		theaterID  string
+		roomID     string
+		templateID string
+	}{
+		{
+			name: "ok",
+			body: ScheduleTemplateRequest{
+				Weekday:   2,
+				StartTime: "19:15",
+			},
+			status:     http.StatusOK,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			templateID: "4dc3081b-4fbb-4da7-90da-a9fc7e0ce572",
+		},
+		{
+			name: "validation-errors",
+			body: ScheduleTemplateRequest{
+				Weekday:   9,
+				StartTime: "25:99",
+				MovieID:   "abc",
+			},
+			status:     http.StatusBadRequest,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			templateID: "4dc3081b-4fbb-4da7-90da-a9fc7e0ce572",
+		},
+		{
+			name: "invalid-template-id",
+			body: ScheduleTemplateRequest{
+				Weekday:   2,
+				StartTime: "19:15",
+			},
+			status:     http.StatusNotFound,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			templateID: "01234567-0123-0123-0123-0123456789ab",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s/templates/%s", testCase.theaterID, testCase.roomID, testCase.templateID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPut, testCase.body)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			ignoreResp := xtesting.ValuesCheckers{
+				"updated_at": xtesting.ValueTimeInPastDuration(time.Second),
+			}
+
+			ignoreTemplates := xtesting.GenerateValueCheckersForArrays(map[string]xtesting.ValueChecker{"UpdatedAt": xtesting.ValueTime()}, 10)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w, ignoreResp)
+			xtesting.AssertGoldenDatabaseTable(t, db.Order("weekday, start_hour"), []models.ScheduleTemplate{}, ignoreTemplates)
+		})
+	}
+}
+
+func TestScheduleTemplatesDelete(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name       string
+		status     int
+		theaterID  string
+		roomID     string
+		templateID string
+	}{
+		{
+			name:       "ok",
+			status:     http.StatusNoContent,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			templateID: "399f6197-6fe9-427f-b3cf-0be0a76f5290",
+		},
+		{
+			name:       "invalid-template-id",
+			status:     http.StatusNotFound,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			templateID: "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:       "malformed-template-id",
+			status:     http.StatusBadRequest,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			templateID: "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s/templates/%s", testCase.theaterID, testCase.roomID, testCase.templateID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodDelete, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+			xtesting.AssertGoldenDatabaseTable(t, db.Order("weekday, start_hour"), []models.ScheduleTemplate{}, nil)
+		})
+	}
+}
@@ -0,0 +1,439 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PRPO-skupina-02/common/database"
+	"github.com/PRPO-skupina-02/common/xtesting"
+	"github.com/PRPO-skupina-02/spored/db"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulePopulate(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name      string
+		status    int
+		theaterID string
+	}{
+		{
+			name:      "ok-no-rooms",
+			status:    http.StatusNoContent,
+			theaterID: "ea0b7f96-ddc9-11f0-9635-23efd36396bd",
+		},
+		{
+			name:      "invalid-theater-id",
+			status:    http.StatusNotFound,
+			theaterID: "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:      "nil-theater-id",
+			status:    http.StatusBadRequest,
+			theaterID: "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			name:      "malformed-theater-id",
+			status:    http.StatusBadRequest,
+			theaterID: "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/schedule/populate", testCase.theaterID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPost, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			ignoreRuns := xtesting.ValuesCheckers{
+				"[3].ID":        xtesting.ValueUUID(),
+				"[3].CreatedAt": xtesting.ValueTime(),
+				"[3].UpdatedAt": xtesting.ValueTime(),
+			}
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+			xtesting.AssertGoldenDatabaseTable(t, db.Order("created_at, id"), []models.ScheduleRun{}, ignoreRuns)
+		})
+	}
+}
+
+func TestScheduleRunsList(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name      string
+		status    int
+		theaterID string
+		params    string
+	}{
+		{
+			name:      "ok",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+		},
+		{
+			name:      "ok-paginated",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?limit=1&offset=1",
+		},
+		{
+			name:      "ok-no-runs",
+			status:    http.StatusOK,
+			theaterID: "ea0b7f96-ddc9-11f0-9635-23efd36396bd",
+		},
+		{
+			name:      "invalid-theater-id",
+			status:    http.StatusNotFound,
+			theaterID: "01234567-0123-0123-0123-0123456789ab",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/schedule/runs%s", testCase.theaterID, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
+
+func TestScheduleRunsShow(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name      string
+		status    int
+		theaterID string
+		runID     string
+	}{
+		{
+			name:      "ok",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			runID:     "3b5d7f91-2c4e-4a6b-8d0f-1e3a5c7e9b02",
+		},
+		{
+			name:      "ok-rooms",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			runID:     "3b5d7f91-2c4e-4a6b-8d0f-1e3a5c7e9b03",
+		},
+		{
+			name:      "other-theater-run",
+			status:    http.StatusNotFound,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			runID:     "3b5d7f91-2c4e-4a6b-8d0f-1e3a5c7e9b03",
+		},
+		{
+			name:      "invalid-run-id",
+			status:    http.StatusNotFound,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			runID:     "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:      "malformed-run-id",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			runID:     "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/schedule/runs/%s", testCase.theaterID, testCase.runID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
+
+func TestScheduleDemand(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name      string
+		status    int
+		theaterID string
+		params    string
+	}{
+		{
+			name:      "ok",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?at=2026-01-06T00:00:00Z",
+		},
+		{
+			name:      "ok-lookback",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?at=2026-01-29T12:00:00Z",
+		},
+		{
+			name:      "ok-little-history",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			params:    "?at=2026-01-06T00:00:00Z",
+		},
+		{
+			name:      "ok-no-history",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?at=2025-12-01T00:00:00Z",
+		},
+		{
+			name:      "invalid-at",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?at=2026-01-06",
+		},
+		{
+			name:      "invalid-theater-id",
+			status:    http.StatusNotFound,
+			theaterID: "01234567-0123-0123-0123-0123456789ab",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/schedule/demand%s", testCase.theaterID, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
+
+func TestScheduleCopy(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name      string
+		status    int
+		theaterID string
+		roomID    string
+		body      any
+		created   int
+		existing  int
+	}{
+		{
+			name:      "ok",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:    "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			body: ScheduleCopyRequest{
+				SourceFrom: "2025-12-30",
+				SourceTo:   "2025-12-30",
+				TargetFrom: "2099-06-02",
+			},
+			created:  2,
+			existing: 14,
+		},
+		{
+			name:      "ok-dry-run",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:    "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			body: ScheduleCopyRequest{
+				SourceFrom: "2025-12-30",
+				SourceTo:   "2025-12-30",
+				TargetFrom: "2099-06-02",
+				DryRun:     true,
+			},
+			created:  2,
+			existing: 14,
+		},
+		{
+			name:      "ok-conflicts",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "e0722c3a-df42-11f0-9579-3734395be62a",
+			body: ScheduleCopyRequest{
+				SourceFrom: "2026-01-03",
+				SourceTo:   "2026-01-03",
+				TargetFrom: "2099-06-06",
+				RoomIDs:    []string{"e0722c3a-df42-11f0-9579-3734395be62a", "e0722c3a-df42-11f0-9579-3734395be62a"},
+			},
+			existing: 36,
+		},
+		{
+			name:      "ok-skipped",
+			status:    http.StatusOK,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "e0a55f7e-df42-11f0-b791-874135af3470",
+			body: ScheduleCopyRequest{
+				SourceFrom: "2025-12-31",
+				SourceTo:   "2025-12-31",
+				TargetFrom: "2099-06-01",
+				RoomIDs:    []string{"e0a55f7e-df42-11f0-b791-874135af3470"},
+			},
+			existing: 16,
+		},
+		{
+			name:      "validation-errors",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:    "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			body: ScheduleCopyRequest{
+				SourceTo: "2025/12/30",
+			},
+			existing: 14,
+		},
+		{
+			name:      "invalid-source-range",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:    "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			body: ScheduleCopyRequest{
+				SourceFrom: "2025-12-31",
+				SourceTo:   "2025-12-30",
+				TargetFrom: "2026-01-06",
+			},
+			existing: 14,
+		},
+		{
+			name:      "source-range-too-long",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:    "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			body: ScheduleCopyRequest{
+				SourceFrom: "2025-12-01",
+				SourceTo:   "2026-01-01",
+				TargetFrom: "2099-06-02",
+			},
+			existing: 14,
+		},
+		{
+			name:      "target-in-past",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:    "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			body: ScheduleCopyRequest{
+				SourceFrom: "2025-12-30",
+				SourceTo:   "2025-12-30",
+				TargetFrom: "2025-12-31",
+			},
+			existing: 14,
+		},
+		{
+			name:      "target-overlaps-source",
+			status:    http.StatusBadRequest,
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "e0722c3a-df42-11f0-9579-3734395be62a",
+			body: ScheduleCopyRequest{
+				SourceFrom: "2099-06-06",
+				SourceTo:   "2099-06-12",
+				TargetFrom: "2099-06-09",
+			},
+			existing: 36,
+		},
+		{
+			name:      "invalid-room-id",
+			status:    http.StatusNotFound,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:    "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			body: ScheduleCopyRequest{
+				SourceFrom: "2025-12-30",
+				TargetFrom: "2099-06-02",
+				RoomIDs:    []string{"925c2358-df46-11f0-a38e-abe580bde3d1"},
+			},
+			existing: 14,
+		},
+		{
+			name:      "no-body",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:    "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			existing:  14,
+		},
+		{
+			name:      "invalid-theater-id",
+			status:    http.StatusNotFound,
+			theaterID: "01234567-0123-0123-0123-0123456789ab",
+			roomID:    "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			existing:  14,
+		},
+		{
+			name:      "nil-theater-id",
+			status:    http.StatusBadRequest,
+			theaterID: "00000000-0000-0000-0000-000000000000",
+			roomID:    "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			existing:  14,
+		},
+		{
+			name:      "malformed-theater-id",
+			status:    http.StatusBadRequest,
+			theaterID: "000",
+			roomID:    "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			existing:  14,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/schedule/copy", testCase.theaterID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPost, testCase.body)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			timeSlotCheckers := map[string]xtesting.ValueChecker{"id": xtesting.ValueUUID(), "created_at": xtesting.ValueTime(), "updated_at": xtesting.ValueTime()}
+			ignoreResp := xtesting.ValuesCheckers{}
+			for path, checker := range xtesting.GenerateValueCheckersForArrays(timeSlotCheckers, testCase.created) {
+				ignoreResp["created."+path] = checker
+			}
+
+			ignoreTimeSlots := xtesting.GenerateValueCheckersForArraysWithOffset(map[string]xtesting.ValueChecker{"ID": xtesting.ValueUUID(), "CreatedAt": xtesting.ValueTime(), "UpdatedAt": xtesting.ValueTime()}, testCase.created, testCase.existing)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w, ignoreResp)
+			xtesting.AssertGoldenDatabaseTable(t, db.Where("room_id = ?", testCase.roomID).Order("start_time"), []models.TimeSlot{}, ignoreTimeSlots)
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SeatResponse struct {
+	ID     uuid.UUID `json:"id"`
+	Column int       `json:"column"`
+	// Number of the seat within its row, aisles are not numbered
+	Number   *int   `json:"number"`
+	Type     string `json:"type"`
+	Disabled bool   `json:"disabled"`
+	// Availability of the seat for a time slot
+	Status string `json:"status,omitempty" enums:"AVAILABLE,HELD,RESERVED,UNAVAILABLE"`
+}
+
+type SeatRowResponse struct {
+	Row   int            `json:"row"`
+	Label string         `json:"label"`
+	Seats []SeatResponse `json:"seats"`
+}
+
+type SeatMapResponse struct {
+	RoomID   uuid.UUID         `json:"room_id"`
+	Rows     int               `json:"rows"`
+	Columns  int               `json:"columns"`
+	Capacity int               `json:"capacity"`
+	SeatRows []SeatRowResponse `json:"seat_rows"`
+}
+
+// newSeatMapResponse groups seats ordered by row and column into rows
+func newSeatMapResponse(room models.Room, seats []models.Seat) SeatMapResponse {
+	response := SeatMapResponse{
+		RoomID:   room.ID,
+		Rows:     room.Rows,
+		Columns:  room.Columns,
+		Capacity: room.Capacity,
+		SeatRows: []SeatRowResponse{},
+	}
+
+	number := 0
+	for _, seat := range seats {
+		if len(response.SeatRows) == 0 || response.SeatRows[len(response.SeatRows)-1].Row != seat.Row {
+			response.SeatRows = append(response.SeatRows, SeatRowResponse{
+				Row:   seat.Row,
+				Label: seat.RowLabel,
+				Seats: []SeatResponse{},
+			})
+			number = 0
+		}
+
+		var seatNumber *int
+		if seat.Type != models.AisleSeat {
+			number++
+			n := number
+			seatNumber = &n
+		}
+
+		row := &response.SeatRows[len(response.SeatRows)-1]
+		row.Seats = append(row.Seats, SeatResponse{
+			ID:       seat.ID,
+			Column:   seat.Column,
+			Number:   seatNumber,
+			Type:     string(seat.Type),
+			Disabled: seat.Disabled,
+		})
+	}
+
+	return response
+}
+
+// RoomSeatsShow
+//
+//	@Id				RoomSeatsShow
+//	@Summary		Show seat map
+//	@Description	Show the seats of a room grouped by row, aisles are not numbered and do not count towards the capacity, neither do disabled seats
+//	@Tags			seats
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string	true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path		string	true	"Room ID"		Format(uuid)
+//	@Success		200			{object}	SeatMapResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/seats [get]
+func RoomSeatsShow(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+
+	seats, err := models.GetRoomSeats(tx, room.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newSeatMapResponse(room, seats))
+}
+
+type SeatRequest struct {
+	Column   int    `json:"column" binding:"required,min=1,max=100"`
+	Type     string `json:"type" binding:"required,oneof=STANDARD VIP WHEELCHAIR AISLE" enums:"STANDARD,VIP,WHEELCHAIR,AISLE"`
+	Disabled bool   `json:"disabled"`
+}
+
+type SeatRowRequest struct {
+	Row int `json:"row" binding:"required,min=1,max=100"`
+	// Label of the row, the current one is kept when empty
+	Label string        `json:"label" binding:"omitempty,max=3,alphanum"`
+	Seats []SeatRequest `json:"seats" binding:"omitempty,dive"`
+}
+
+type SeatMapRequest struct {
+	SeatRows []SeatRowRequest `json:"seat_rows" binding:"required,min=1,dive"`
+}
+
+// RoomSeatsUpdate
+//
+//	@Id				RoomSeatsUpdate
+//	@Summary		Update seat map
+//	@Description	Update labels of the given rows and types of the given seats, rows and seats that are not given are kept as they are. Changing the type of seats or disabling them is refused with a conflict listing the future time slots that have reservations for those seats, unless forced, which cancels those time slots
+//	@Tags			seats
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string			true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path		string			true	"Room ID"		Format(uuid)
+//	@Param			force		query		bool			false	"Cancel time slots with reservations for changed seats"
+//	@Param			request		body		SeatMapRequest	true	"request body"
+//	@Success		200			{object}	SeatMapResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		409			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/seats [put]
+func RoomSeatsUpdate(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+
+	force, err := getForce(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req SeatMapRequest
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	seats, err := models.GetRoomSeats(tx, room.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	type position struct{ row, column int }
+	positions := map[position]int{}
+	labels := map[int]string{}
+	for i, seat := range seats {
+		positions[position{seat.Row, seat.Column}] = i
+		labels[seat.Row] = seat.RowLabel
+	}
+
+	changed := map[int]bool{}
+	retyped := []uuid.UUID{}
+	for _, row := range req.SeatRows {
+		if row.Row > room.Rows {
+			_ = c.Error(middleware.NewBadRequestError(fmt.Sprintf("Row %d is outside of the room", row.Row)))
+			return
+		}
+
+		if row.Label != "" && row.Label != labels[row.Row] {
+			labels[row.Row] = row.Label
+			for column := 1; column <= room.Columns; column++ {
+				if i, ok := positions[position{row.Row, column}]; ok {
+					seats[i].RowLabel = row.Label
+					changed[i] = true
+				}
+			}
+		}
+
+		for _, seat := range row.Seats {
+			i, ok := positions[position{row.Row, seat.Column}]
+			if !ok {
+				_ = c.Error(middleware.NewBadRequestError(fmt.Sprintf("Seat %d of row %d is outside of the room", seat.Column, row.Row)))
+				return
+			}
+
+			if seats[i].Type != models.SeatType(seat.Type) || seats[i].Disabled != seat.Disabled {
+				retyped = append(retyped, seats[i].ID)
+			}
+
+			seats[i].Type = models.SeatType(seat.Type)
+			seats[i].Disabled = seat.Disabled
+			changed[i] = true
+		}
+	}
+
+	labelledRows := map[string]int{}
+	for row := 1; row <= room.Rows; row++ {
+		label := labels[row]
+		if other, ok := labelledRows[label]; ok {
+			_ = c.Error(middleware.NewBadRequestError(fmt.Sprintf("Rows %d and %d have the same label %s", other, row, label)))
+			return
+		}
+		labelledRows[label] = row
+	}
+
+	err = room.PrepareSeatChanges(tx, retyped, force, time.Now())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	for i := range seats {
+		if !changed[i] {
+			continue
+		}
+
+		err = seats[i].Save(tx)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+
+	err = room.UpdateCapacity(tx)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newSeatMapResponse(room, seats))
+}
+
+type SeatPlanResponse struct {
+	Rows     int `json:"rows"`
+	Columns  int `json:"columns"`
+	Capacity int `json:"capacity"`
+	// Imported seat plan with row labels, disabled seats are marked with X
+	Preview []string `json:"preview"`
+}
+
+// Seat plans of the largest room with a line break after every row, with a
+// row to spare for carriage returns and trailing blank lines
+const maxSeatPlanBytes = (models.MaxRoomColumns + 1) * (models.MaxRoomRows + 1)
+
+// RoomSeatPlanImport
+//
+//	@Id				RoomSeatPlanImport
+//	@Summary		Import seat plan
+//	@Description	Replace the layout of a room with a text seat plan of one line per row and one character per seat: S for standard, V for VIP and W for wheelchair seats, a dot or a space for gaps. The room is resized to the plan, which can be at most 100 by 100 seats, while row labels and disabled seats are kept. Plans that remove or change seats with reservations for future time slots are refused with a conflict listing those time slots, unless forced, which cancels them. Removed seats with confirmed reservations are disabled and kept for the reservation history
+//	@Tags			seats
+//	@Accept			plain
+//	@Produce		json
+//	@Param			theaterID	path		string	true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path		string	true	"Room ID"		Format(uuid)
+//	@Param			force		query		bool	false	"Cancel time slots with reservations for removed or changed seats"
+//	@Param			request		body		string	true	"seat plan"
+//	@Success		200			{object}	SeatPlanResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		409			{object}	middleware.HttpError
+//	@Failure		413			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/seats/plan [put]
+func RoomSeatPlanImport(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+
+	force, err := getForce(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSeatPlanBytes)
+	body, err := c.GetRawData()
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		_ = c.Error(&middleware.HttpError{
+			Code:    http.StatusRequestEntityTooLarge,
+			Message: fmt.Sprintf("Seat plan must not be larger than %d bytes", maxSeatPlanBytes),
+		})
+		return
+	}
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	plan, err := models.ParseSeatPlan(string(body))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = room.ApplySeatPlan(tx, plan, force, time.Now())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	seats, err := models.GetRoomSeats(tx, room.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SeatPlanResponse{
+		Rows:     room.Rows,
+		Columns:  room.Columns,
+		Capacity: room.Capacity,
+		Preview:  models.RenderSeatPlan(seats),
+	})
+}
@@ -0,0 +1,419 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	authmodels "github.com/PRPO-skupina-02/common/clients/auth/models"
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/PRPO-skupina-02/common/request"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	AvailableSeat   = "AVAILABLE"
+	HeldSeat        = "HELD"
+	ReservedSeat    = "RESERVED"
+	UnavailableSeat = "UNAVAILABLE"
+)
+
+type SeatAvailabilityResponse struct {
+	TimeSlotID uuid.UUID         `json:"time_slot_id"`
+	Capacity   int               `json:"capacity"`
+	Available  int               `json:"available"`
+	Held       int               `json:"held"`
+	Reserved   int               `json:"reserved"`
+	SeatRows   []SeatRowResponse `json:"seat_rows"`
+}
+
+func newSeatAvailabilityResponse(room models.Room, timeSlot models.TimeSlot, seats []models.Seat, reservations []models.SeatReservation) SeatAvailabilityResponse {
+	statuses := map[uuid.UUID]string{}
+	for _, reservation := range reservations {
+		if reservation.Status == models.ConfirmedSeatReservation {
+			statuses[reservation.SeatID] = ReservedSeat
+		} else {
+			statuses[reservation.SeatID] = HeldSeat
+		}
+	}
+
+	response := SeatAvailabilityResponse{
+		TimeSlotID: timeSlot.ID,
+		Capacity:   room.Capacity,
+		SeatRows:   newSeatMapResponse(room, seats).SeatRows,
+	}
+
+	for i := range response.SeatRows {
+		for j := range response.SeatRows[i].Seats {
+			seat := &response.SeatRows[i].Seats[j]
+
+			status, ok := statuses[seat.ID]
+			switch {
+			case ok:
+			case seat.Type == string(models.AisleSeat) || seat.Disabled:
+				status = UnavailableSeat
+			default:
+				status = AvailableSeat
+			}
+
+			switch status {
+			case AvailableSeat:
+				response.Available++
+			case HeldSeat:
+				response.Held++
+			case ReservedSeat:
+				response.Reserved++
+			}
+
+			seat.Status = status
+		}
+	}
+
+	return response
+}
+
+type SeatHoldResponse struct {
+	HoldID     uuid.UUID   `json:"hold_id"`
+	TimeSlotID uuid.UUID   `json:"time_slot_id"`
+	Status     string      `json:"status" enums:"HELD,CONFIRMED"`
+	ExpiresAt  *time.Time  `json:"expires_at"`
+	SeatIDs    []uuid.UUID `json:"seat_ids"`
+}
+
+func newSeatHoldResponse(reservations []models.SeatReservation) SeatHoldResponse {
+	response := SeatHoldResponse{
+		HoldID:     reservations[0].HoldID,
+		TimeSlotID: reservations[0].TimeSlotID,
+		Status:     string(reservations[0].Status),
+		ExpiresAt:  reservations[0].ExpiresAt,
+		SeatIDs:    []uuid.UUID{},
+	}
+
+	for _, reservation := range reservations {
+		response.SeatIDs = append(response.SeatIDs, reservation.SeatID)
+	}
+
+	return response
+}
+
+// getBookableTimeSlot returns the time slot of the context room, as long as
+// its seats can still be booked
+func getBookableTimeSlot(c *gin.Context, now time.Time) (models.TimeSlot, error) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	timeSlotID, err := request.GetUUIDParam(c, "timeSlotID")
+	if err != nil {
+		return models.TimeSlot{}, err
+	}
+
+	timeSlot, err := models.GetTimeSlot(tx, room.ID, timeSlotID)
+	if err != nil {
+		return timeSlot, err
+	}
+
+	if timeSlot.Status != models.ScheduledTimeSlot || !timeSlot.StartTime.After(now) {
+		return timeSlot, middleware.NewBadRequestError("Seats can only be booked for scheduled time slots that have not started yet")
+	}
+
+	return timeSlot, nil
+}
+
+// TimeSlotSeatsAvailability
+//
+//	@Id				TimeSlotSeatsAvailability
+//	@Summary		Show seat availability
+//	@Description	Show the seats of a room grouped by row with their availability for the time slot, expired holds are available again
+//	@Tags			seats
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string	true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path		string	true	"Room ID"		Format(uuid)
+//	@Param			timeSlotID	path		string	true	"TimeSlot ID"	Format(uuid)
+//	@Success		200			{object}	SeatAvailabilityResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/seats [get]
+func TimeSlotSeatsAvailability(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	timeSlotID, err := request.GetUUIDParam(c, "timeSlotID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	timeSlot, err := models.GetTimeSlot(tx, room.ID, timeSlotID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	seats, err := models.GetRoomSeats(tx, room.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	reservations, err := models.GetTimeSlotSeatReservations(tx, timeSlot.ID, time.Now())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newSeatAvailabilityResponse(room, timeSlot, seats, reservations))
+}
+
+type SeatHoldRequest struct {
+	SeatIDs []string `json:"seat_ids" binding:"required,min=1,max=20,dive,uuid,non-nil-uuid"`
+	// Minutes until the hold expires, 10 by default
+	TTLMinutes int `json:"ttl_minutes" binding:"omitempty,min=1,max=30"`
+}
+
+// SeatHoldsCreate
+//
+//	@Id				SeatHoldsCreate
+//	@Summary		Hold seats
+//	@Description	Hold seats for the user for a time slot until the hold is confirmed, released or expires. Seats that are already held or reserved are listed in the fields of the conflict error
+//	@Tags			seats
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string			true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path		string			true	"Room ID"		Format(uuid)
+//	@Param			timeSlotID	path		string			true	"TimeSlot ID"	Format(uuid)
+//	@Param			request		body		SeatHoldRequest	true	"request body"
+//	@Success		201			{object}	SeatHoldResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		409			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/holds [post]
+func SeatHoldsCreate(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	now := time.Now()
+
+	userID := middleware.GetContextUserID(c)
+	if c.IsAborted() {
+		return
+	}
+
+	timeSlot, err := getBookableTimeSlot(c, now)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req SeatHoldRequest
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	seats, err := models.GetRoomSeats(tx, room.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	roomSeats := map[string]models.Seat{}
+	for _, seat := range seats {
+		roomSeats[seat.ID.String()] = seat
+	}
+
+	held := []models.Seat{}
+	for _, seatID := range req.SeatIDs {
+		seat, ok := roomSeats[seatID]
+		if !ok {
+			_ = c.Error(middleware.NewBadRequestError(fmt.Sprintf("Seat %s is not in the room", seatID)))
+			return
+		}
+		if !seat.Usable() {
+			_ = c.Error(middleware.NewBadRequestError(fmt.Sprintf("Seat %s can not be booked", seatID)))
+			return
+		}
+		if slices.ContainsFunc(held, func(s models.Seat) bool { return s.ID == seat.ID }) {
+			_ = c.Error(middleware.NewBadRequestError(fmt.Sprintf("Seat %s is given more than once", seatID)))
+			return
+		}
+
+		held = append(held, seat)
+	}
+
+	slices.SortFunc(held, func(a, b models.Seat) int {
+		if a.Row != b.Row {
+			return a.Row - b.Row
+		}
+		return a.Column - b.Column
+	})
+
+	ttl := models.DefaultSeatHoldTTL
+	if req.TTLMinutes != 0 {
+		ttl = time.Duration(req.TTLMinutes) * time.Minute
+	}
+
+	reservations, err := timeSlot.HoldSeats(tx, held, userID, ttl, now)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, newSeatHoldResponse(reservations))
+}
+
+// SeatHoldsConfirm
+//
+//	@Id				SeatHoldsConfirm
+//	@Summary		Confirm seat hold
+//	@Description	Turn held seats into reservations that do not expire once they are paid for, only admins can confirm holds
+//	@Tags			seats
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string	true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path		string	true	"Room ID"		Format(uuid)
+//	@Param			timeSlotID	path		string	true	"TimeSlot ID"	Format(uuid)
+//	@Param			holdID		path		string	true	"Hold ID"		Format(uuid)
+//	@Success		200			{object}	SeatHoldResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		409			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/holds/{holdID}/confirm [post]
+func SeatHoldsConfirm(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	now := time.Now()
+
+	timeSlot, err := getBookableTimeSlot(c, now)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	holdID, err := request.GetUUIDParam(c, "holdID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	reservations, err := models.ConfirmSeatHold(tx, timeSlot.ID, holdID, now)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newSeatHoldResponse(reservations))
+}
+
+// SeatHoldsRelease
+//
+//	@Id				SeatHoldsRelease
+//	@Summary		Release seat hold
+//	@Description	Release held seats of a hold of the user, admins can release any hold. Confirmed reservations can not be released, admins cancel them instead
+//	@Tags			seats
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path	string	true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path	string	true	"Room ID"		Format(uuid)
+//	@Param			timeSlotID	path	string	true	"TimeSlot ID"	Format(uuid)
+//	@Param			holdID		path	string	true	"Hold ID"		Format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	middleware.HttpError
+//	@Failure		404	{object}	middleware.HttpError
+//	@Failure		500	{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/holds/{holdID} [delete]
+func SeatHoldsRelease(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	timeSlotID, err := request.GetUUIDParam(c, "timeSlotID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	holdID, err := request.GetUUIDParam(c, "holdID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	timeSlot, err := models.GetTimeSlot(tx, room.ID, timeSlotID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	userID := middleware.GetContextUserID(c)
+	if c.IsAborted() {
+		return
+	}
+
+	scopes := []func(*gorm.DB) *gorm.DB{}
+	if middleware.GetContextUserRole(c) != authmodels.ModelsUserRoleAdmin {
+		scopes = append(scopes, models.SeatHoldOwnerScope(userID))
+	}
+
+	err = models.ReleaseSeatHold(tx, timeSlot.ID, holdID, scopes...)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, "")
+}
+
+// SeatReservationsCancel
+//
+//	@Id				SeatReservationsCancel
+//	@Summary		Cancel seat reservation
+//	@Description	Cancel the confirmed reservations of a hold, so that the seats can be booked again. Only reservations for time slots that have not started yet can be cancelled
+//	@Tags			seats
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path	string	true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path	string	true	"Room ID"		Format(uuid)
+//	@Param			timeSlotID	path	string	true	"TimeSlot ID"	Format(uuid)
+//	@Param			holdID		path	string	true	"Hold ID"		Format(uuid)
+//	@Success		204
+//	@Failure		400	{object}	middleware.HttpError
+//	@Failure		404	{object}	middleware.HttpError
+//	@Failure		500	{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/reservations/{holdID} [delete]
+func SeatReservationsCancel(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	timeSlotID, err := request.GetUUIDParam(c, "timeSlotID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	holdID, err := request.GetUUIDParam(c, "holdID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	timeSlot, err := models.GetTimeSlot(tx, room.ID, timeSlotID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if !timeSlot.StartTime.After(time.Now()) {
+		_ = c.Error(middleware.NewBadRequestError("Only reservations for time slots that have not started yet can be cancelled"))
+		return
+	}
+
+	err = models.CancelSeatReservation(tx, timeSlot.ID, holdID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, "")
+}
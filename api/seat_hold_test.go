@@ -0,0 +1,412 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PRPO-skupina-02/common/database"
+	"github.com/PRPO-skupina-02/common/xtesting"
+	"github.com/PRPO-skupina-02/spored/db"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func orderedSeatReservations(db *gorm.DB) *gorm.DB {
+	return db.Joins("JOIN seats ON seats.id = seat_reservations.seat_id").Order("seat_reservations.time_slot_id").Order(`seats."row"`).Order(`seats."column"`)
+}
+
+func TestTimeSlotSeatsAvailability(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name       string
+		status     int
+		roomID     string
+		timeSlotID string
+	}{
+		{
+			name:       "ok",
+			status:     http.StatusOK,
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
+		},
+		{
+			name:       "time-slot-from-different-room",
+			status:     http.StatusNotFound,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
+		},
+		{
+			name:       "invalid-time-slot-id",
+			status:     http.StatusNotFound,
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:       "malformed-time-slot-id",
+			status:     http.StatusBadRequest,
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/bae209f6-d059-11f0-b2a4-cbf992c2eb6d/rooms/%s/timeslots/%s/seats", testCase.roomID, testCase.timeSlotID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
+
+func TestSeatHoldsCreate(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := CustomerTestingRouter(t, db)
+
+	newReservation := map[string]xtesting.ValueChecker{
+		"ID":        xtesting.ValueUUID(),
+		"HoldID":    xtesting.ValueUUID(),
+		"CreatedAt": xtesting.ValueTime(),
+		"UpdatedAt": xtesting.ValueTime(),
+		"ExpiresAt": xtesting.ValueTime(),
+	}
+
+	tests := []struct {
+		name               string
+		status             int
+		roomID             string
+		timeSlotID         string
+		body               any
+		ignoreReservations xtesting.ValuesCheckers
+	}{
+		{
+			name:       "ok",
+			status:     http.StatusCreated,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			body: SeatHoldRequest{
+				SeatIDs: []string{"3c52796e-6e11-52b3-a886-725758fd56a5", "0544afbd-71f6-5070-864c-e2db5ebc45d8"},
+			},
+			ignoreReservations: xtesting.GenerateValueCheckersForArraysWithOffset(newReservation, 2, 6),
+		},
+		{
+			name:       "ok-expired-hold",
+			status:     http.StatusCreated,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			body: SeatHoldRequest{
+				SeatIDs:    []string{"e56bcce1-daae-5e2c-afef-93950819daaa"},
+				TTLMinutes: 5,
+			},
+			ignoreReservations: xtesting.GenerateValueCheckersForArraysWithOffset(newReservation, 1, 6),
+		},
+		{
+			name:       "seats-not-available",
+			status:     http.StatusConflict,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			body: SeatHoldRequest{
+				SeatIDs: []string{"c6bb72c4-3988-5569-9450-e279224ae3b6", "3939d65c-3aa6-5025-b93c-4c3a66b3b118", "3c52796e-6e11-52b3-a886-725758fd56a5"},
+			},
+		},
+		{
+			name:       "seat-not-in-room",
+			status:     http.StatusBadRequest,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			body: SeatHoldRequest{
+				SeatIDs: []string{"af678b75-7c95-5695-9cc2-fad0140a50f7"},
+			},
+		},
+		{
+			name:       "duplicate-seat",
+			status:     http.StatusBadRequest,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			body: SeatHoldRequest{
+				SeatIDs: []string{"3c52796e-6e11-52b3-a886-725758fd56a5", "3c52796e-6e11-52b3-a886-725758fd56a5"},
+			},
+		},
+		{
+			name:       "past-time-slot",
+			status:     http.StatusBadRequest,
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
+			body: SeatHoldRequest{
+				SeatIDs: []string{"6b1cecb4-7001-5ea1-ad13-d62ff49b7449"},
+			},
+		},
+		{
+			name:       "validation-errors",
+			status:     http.StatusBadRequest,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			body: SeatHoldRequest{
+				SeatIDs:    []string{"abc"},
+				TTLMinutes: 60,
+			},
+		},
+		{
+			name:       "no-body",
+			status:     http.StatusBadRequest,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+		},
+		{
+			name:       "invalid-time-slot-id",
+			status:     http.StatusNotFound,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "01234567-0123-0123-0123-0123456789ab",
+			body: SeatHoldRequest{
+				SeatIDs: []string{"3c52796e-6e11-52b3-a886-725758fd56a5"},
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/bae209f6-d059-11f0-b2a4-cbf992c2eb6d/rooms/%s/timeslots/%s/holds", testCase.roomID, testCase.timeSlotID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPost, testCase.body)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			ignoreResp := xtesting.ValuesCheckers{
+				"hold_id":    xtesting.ValueUUID(),
+				"expires_at": xtesting.ValueTime(),
+			}
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w, ignoreResp)
+			xtesting.AssertGoldenDatabaseTable(t, orderedSeatReservations(db), []models.SeatReservation{}, testCase.ignoreReservations)
+		})
+	}
+}
+
+func TestSeatHoldsConfirm(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name               string
+		status             int
+		roomID             string
+		timeSlotID         string
+		holdID             string
+		ignoreReservations xtesting.ValuesCheckers
+	}{
+		{
+			name:               "ok",
+			status:             http.StatusOK,
+			roomID:             "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID:         "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			holdID:             "7b0d5e3a-1c2f-4a8b-9e6d-0a1b2c3d4e05",
+			ignoreReservations: xtesting.ValuesCheckers{"[5].UpdatedAt": xtesting.ValueTime()},
+		},
+		{
+			name:       "expired-hold",
+			status:     http.StatusConflict,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			holdID:     "7b0d5e3a-1c2f-4a8b-9e6d-0a1b2c3d4e06",
+		},
+		{
+			name:       "past-time-slot",
+			status:     http.StatusBadRequest,
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
+			holdID:     "7b0d5e3a-1c2f-4a8b-9e6d-0a1b2c3d4e02",
+		},
+		{
+			name:       "hold-from-different-time-slot",
+			status:     http.StatusNotFound,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			holdID:     "7b0d5e3a-1c2f-4a8b-9e6d-0a1b2c3d4e02",
+		},
+		{
+			name:       "malformed-hold-id",
+			status:     http.StatusBadRequest,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			holdID:     "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/bae209f6-d059-11f0-b2a4-cbf992c2eb6d/rooms/%s/timeslots/%s/holds/%s/confirm", testCase.roomID, testCase.timeSlotID, testCase.holdID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPost, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+			xtesting.AssertGoldenDatabaseTable(t, orderedSeatReservations(db), []models.SeatReservation{}, testCase.ignoreReservations)
+		})
+	}
+}
+
+func TestSeatHoldsRelease(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+	customer := CustomerTestingRouter(t, db)
+
+	tests := []struct {
+		name       string
+		status     int
+		customer   bool
+		timeSlotID string
+		holdID     string
+	}{
+		{
+			name:       "ok",
+			status:     http.StatusNoContent,
+			customer:   true,
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			holdID:     "7b0d5e3a-1c2f-4a8b-9e6d-0a1b2c3d4e05",
+		},
+		{
+			name:       "ok-admin",
+			status:     http.StatusNoContent,
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			holdID:     "7b0d5e3a-1c2f-4a8b-9e6d-0a1b2c3d4e06",
+		},
+		{
+			name:       "hold-of-different-user",
+			status:     http.StatusNotFound,
+			customer:   true,
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			holdID:     "7b0d5e3a-1c2f-4a8b-9e6d-0a1b2c3d4e06",
+		},
+		{
+			name:       "confirmed-hold",
+			status:     http.StatusBadRequest,
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			holdID:     "7b0d5e3a-1c2f-4a8b-9e6d-0a1b2c3d4e04",
+		},
+		{
+			name:       "invalid-hold-id",
+			status:     http.StatusNotFound,
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			holdID:     "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:       "malformed-hold-id",
+			status:     http.StatusBadRequest,
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			holdID:     "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/bae209f6-d059-11f0-b2a4-cbf992c2eb6d/rooms/e0722c3a-df42-11f0-9579-3734395be62a/timeslots/%s/holds/%s", testCase.timeSlotID, testCase.holdID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodDelete, nil)
+			w := httptest.NewRecorder()
+
+			if testCase.customer {
+				customer.ServeHTTP(w, req)
+			} else {
+				r.ServeHTTP(w, req)
+			}
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+			xtesting.AssertGoldenDatabaseTable(t, orderedSeatReservations(db), []models.SeatReservation{}, nil)
+		})
+	}
+}
+
+func TestSeatReservationsCancel(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name       string
+		status     int
+		roomID     string
+		timeSlotID string
+		holdID     string
+	}{
+		{
+			name:       "ok",
+			status:     http.StatusNoContent,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			holdID:     "7b0d5e3a-1c2f-4a8b-9e6d-0a1b2c3d4e04",
+		},
+		{
+			name:       "held-hold",
+			status:     http.StatusBadRequest,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			holdID:     "7b0d5e3a-1c2f-4a8b-9e6d-0a1b2c3d4e05",
+		},
+		{
+			name:       "past-time-slot",
+			status:     http.StatusBadRequest,
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
+			holdID:     "7b0d5e3a-1c2f-4a8b-9e6d-0a1b2c3d4e01",
+		},
+		{
+			name:       "invalid-hold-id",
+			status:     http.StatusNotFound,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			holdID:     "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:       "malformed-hold-id",
+			status:     http.StatusBadRequest,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			holdID:     "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/bae209f6-d059-11f0-b2a4-cbf992c2eb6d/rooms/%s/timeslots/%s/reservations/%s", testCase.roomID, testCase.timeSlotID, testCase.holdID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodDelete, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+			xtesting.AssertGoldenDatabaseTable(t, orderedSeatReservations(db), []models.SeatReservation{}, nil)
+		})
+	}
+}
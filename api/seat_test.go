@@ -0,0 +1,360 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PRPO-skupina-02/common/database"
+	"github.com/PRPO-skupina-02/common/xtesting"
+	"github.com/PRPO-skupina-02/spored/db"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoomSeatsShow(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name      string
+		status    int
+		roomID    string
+		theaterID string
+	}{
+		{
+			name:      "ok",
+			status:    http.StatusOK,
+			roomID:    "e0a55f7e-df42-11f0-b791-874135af3470",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+		},
+		{
+			name:      "room-from-different-theater",
+			status:    http.StatusNotFound,
+			roomID:    "e0a55f7e-df42-11f0-b791-874135af3470",
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+		},
+		{
+			name:      "invalid-room-id",
+			status:    http.StatusNotFound,
+			roomID:    "01234567-0123-0123-0123-0123456789ab",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+		},
+		{
+			name:      "nil-room-id",
+			status:    http.StatusBadRequest,
+			roomID:    "00000000-0000-0000-0000-000000000000",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+		},
+		{
+			name:      "malformed-room-id",
+			status:    http.StatusBadRequest,
+			roomID:    "000",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s/seats", testCase.theaterID, testCase.roomID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
+
+func TestRoomSeatsUpdate(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name   string
+		status int
+		roomID string
+		params string
+		body   any
+	}{
+		{
+			name:   "ok",
+			status: http.StatusOK,
+			roomID: "e0a55f7e-df42-11f0-b791-874135af3470",
+			body: SeatMapRequest{
+				SeatRows: []SeatRowRequest{
+					{
+						Row:   1,
+						Label: "R1",
+					},
+					{
+						Row: 2,
+						Seats: []SeatRequest{
+							{Column: 1, Type: string(models.WheelchairSeat)},
+							{Column: 2, Type: string(models.StandardSeat), Disabled: true},
+							{Column: 4, Type: string(models.StandardSeat), Disabled: true},
+						},
+					},
+					{
+						Row: 3,
+						Seats: []SeatRequest{
+							{Column: 3, Type: string(models.VIPSeat)},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "reserved-seats",
+			status: http.StatusConflict,
+			roomID: "e0722c3a-df42-11f0-9579-3734395be62a",
+			body: SeatMapRequest{
+				SeatRows: []SeatRowRequest{
+					{
+						Row: 1,
+						Seats: []SeatRequest{
+							{Column: 2, Type: string(models.StandardSeat), Disabled: true},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "invalid-force",
+			status: http.StatusBadRequest,
+			roomID: "e0722c3a-df42-11f0-9579-3734395be62a",
+			params: "?force=maybe",
+			body: SeatMapRequest{
+				SeatRows: []SeatRowRequest{
+					{Row: 1, Label: "R1"},
+				},
+			},
+		},
+		{
+			name:   "duplicate-label",
+			status: http.StatusBadRequest,
+			roomID: "e0a55f7e-df42-11f0-b791-874135af3470",
+			body: SeatMapRequest{
+				SeatRows: []SeatRowRequest{
+					{Row: 2, Label: "C"},
+				},
+			},
+		},
+		{
+			name:   "row-outside-of-room",
+			status: http.StatusBadRequest,
+			roomID: "e0a55f7e-df42-11f0-b791-874135af3470",
+			body: SeatMapRequest{
+				SeatRows: []SeatRowRequest{
+					{Row: 4, Label: "D"},
+				},
+			},
+		},
+		{
+			name:   "seat-outside-of-room",
+			status: http.StatusBadRequest,
+			roomID: "e0a55f7e-df42-11f0-b791-874135af3470",
+			body: SeatMapRequest{
+				SeatRows: []SeatRowRequest{
+					{
+						Row: 1,
+						Seats: []SeatRequest{
+							{Column: 6, Type: string(models.VIPSeat)},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "validation-errors",
+			status: http.StatusBadRequest,
+			roomID: "e0a55f7e-df42-11f0-b791-874135af3470",
+			body: SeatMapRequest{
+				SeatRows: []SeatRowRequest{
+					{
+						Row:   101,
+						Label: "ABCD",
+						Seats: []SeatRequest{
+							{Column: 0, Type: "SOFA"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "no-body",
+			status: http.StatusBadRequest,
+			roomID: "e0a55f7e-df42-11f0-b791-874135af3470",
+		},
+		{
+			name:   "invalid-room-id",
+			status: http.StatusNotFound,
+			roomID: "01234567-0123-0123-0123-0123456789ab",
+			body: SeatMapRequest{
+				SeatRows: []SeatRowRequest{
+					{Row: 1, Label: "R1"},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/bae209f6-d059-11f0-b2a4-cbf992c2eb6d/rooms/%s/seats%s", testCase.roomID, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPut, testCase.body)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			ignoreSeats := xtesting.GenerateValueCheckersForArrays(map[string]xtesting.ValueChecker{"UpdatedAt": xtesting.ValueTime()}, 15)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+			xtesting.AssertGoldenDatabaseTable(t, db.Where("room_id = ?", "e0a55f7e-df42-11f0-b791-874135af3470").Order(`"row"`).Order(`"column"`), []models.Seat{}, ignoreSeats)
+		})
+	}
+}
+
+func TestRoomSeatPlanImport(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	newSeat := map[string]xtesting.ValueChecker{"ID": xtesting.ValueUUID(), "CreatedAt": xtesting.ValueTime()}
+
+	tests := []struct {
+		name        string
+		status      int
+		roomID      string
+		params      string
+		plan        string
+		ignoreSeats xtesting.ValuesCheckers
+	}{
+		{
+			name:   "ok",
+			status: http.StatusOK,
+			roomID: "e0a55f7e-df42-11f0-b791-874135af3470",
+			plan:   "SS.SSS\nSS.SSS\n\nWV.VVW\n",
+			ignoreSeats: func() xtesting.ValuesCheckers {
+				checkers := xtesting.GenerateValueCheckersForArraysWithOffset(newSeat, 6, 18)
+				for _, i := range []int{5, 11, 17} {
+					for path, checker := range xtesting.GenerateValueCheckersForArraysWithOffset(newSeat, 1, i) {
+						checkers[path] = checker
+					}
+				}
+				return checkers
+			}(),
+		},
+		{
+			name:   "ok-shrink",
+			status: http.StatusOK,
+			roomID: "e0a55f7e-df42-11f0-b791-874135af3470",
+			plan:   "SSS\nVVV",
+		},
+		{
+			name:   "ok-force",
+			status: http.StatusOK,
+			roomID: "e0722c3a-df42-11f0-9579-3734395be62a",
+			params: "?force=true",
+			plan:   "VSS",
+		},
+		{
+			name:   "ok-keep-reservation-history",
+			status: http.StatusOK,
+			roomID: "925c2358-df46-11f0-a38e-abe580bde3d1",
+			plan:   "S",
+		},
+		{
+			name:   "reserved-seats-removed",
+			status: http.StatusConflict,
+			roomID: "e0722c3a-df42-11f0-9579-3734395be62a",
+			plan:   "S",
+		},
+		{
+			name:   "reserved-seats-changed",
+			status: http.StatusConflict,
+			roomID: "e0722c3a-df42-11f0-9579-3734395be62a",
+			plan:   "VSS",
+		},
+		{
+			name:   "invalid-force",
+			status: http.StatusBadRequest,
+			roomID: "e0a55f7e-df42-11f0-b791-874135af3470",
+			params: "?force=maybe",
+			plan:   "SSS",
+		},
+		{
+			name:   "empty",
+			status: http.StatusBadRequest,
+			roomID: "e0a55f7e-df42-11f0-b791-874135af3470",
+			plan:   "\n   \n",
+		},
+		{
+			name:   "unknown-character",
+			status: http.StatusBadRequest,
+			roomID: "e0a55f7e-df42-11f0-b791-874135af3470",
+			plan:   "SS.SS\nSSQSS",
+		},
+		{
+			name:   "too-many-rows",
+			status: http.StatusBadRequest,
+			roomID: "e0a55f7e-df42-11f0-b791-874135af3470",
+			plan:   strings.Repeat("S\n", 101),
+		},
+		{
+			name:   "too-many-columns",
+			status: http.StatusBadRequest,
+			roomID: "e0a55f7e-df42-11f0-b791-874135af3470",
+			plan:   strings.Repeat("S", 101),
+		},
+		{
+			name:   "too-large",
+			status: http.StatusRequestEntityTooLarge,
+			roomID: "e0a55f7e-df42-11f0-b791-874135af3470",
+			plan:   strings.Repeat(strings.Repeat("S", 100)+"\n", 200),
+		},
+		{
+			name:   "invalid-room-id",
+			status: http.StatusNotFound,
+			roomID: "01234567-0123-0123-0123-0123456789ab",
+			plan:   "SSS",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/bae209f6-d059-11f0-b2a4-cbf992c2eb6d/rooms/%s/seats/plan%s", testCase.roomID, testCase.params)
+
+			req, err := http.NewRequest(http.MethodPut, targetURL, strings.NewReader(testCase.plan))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "text/plain")
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			ignoreSeats := xtesting.GenerateValueCheckersForArrays(map[string]xtesting.ValueChecker{"UpdatedAt": xtesting.ValueTime()}, 24)
+			for path, checker := range testCase.ignoreSeats {
+				ignoreSeats[path] = checker
+			}
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+			xtesting.AssertGoldenDatabaseTable(t, db.Where("room_id = ?", "e0a55f7e-df42-11f0-b791-874135af3470").Order(`"row"`).Order(`"column"`), []models.Seat{}, ignoreSeats)
+			xtesting.AssertGoldenDatabaseTable(t, orderedSeatReservations(db), []models.SeatReservation{}, nil)
+		})
+	}
+}
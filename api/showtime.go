@@ -0,0 +1,144 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/PRPO-skupina-02/common/request"
+	"github.com/PRPO-skupina-02/spored/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ShowtimeResponse struct {
+	ID        uuid.UUID `json:"id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	RoomID    uuid.UUID `json:"room_id"`
+	RoomName  string    `json:"room_name"`
+}
+
+type ShowtimeDayResponse struct {
+	Date      string             `json:"date" format:"date"`
+	Showtimes []ShowtimeResponse `json:"showtimes"`
+}
+
+type TheaterShowtimesResponse struct {
+	TheaterID   uuid.UUID             `json:"theater_id"`
+	TheaterName string                `json:"theater_name"`
+	Days        []ShowtimeDayResponse `json:"days"`
+}
+
+type MovieShowtimesResponse struct {
+	Movie    MovieSummaryResponse       `json:"movie"`
+	Theaters []TheaterShowtimesResponse `json:"theaters"`
+}
+
+// newMovieShowtimesResponse groups time slots ordered by start time by theater
+// and day, theaters are listed in order of their first showtime
+func newMovieShowtimesResponse(movie models.Movie, timeSlots []models.TimeSlot) MovieShowtimesResponse {
+	response := MovieShowtimesResponse{
+		Movie:    newMovieSummaryResponse(movie),
+		Theaters: []TheaterShowtimesResponse{},
+	}
+
+	theaterIndex := map[uuid.UUID]int{}
+
+	for _, timeSlot := range timeSlots {
+		index, ok := theaterIndex[timeSlot.Room.TheaterID]
+		if !ok {
+			index = len(response.Theaters)
+			theaterIndex[timeSlot.Room.TheaterID] = index
+			response.Theaters = append(response.Theaters, TheaterShowtimesResponse{
+				TheaterID:   timeSlot.Room.TheaterID,
+				TheaterName: timeSlot.Room.Theater.Name,
+				Days:        []ShowtimeDayResponse{},
+			})
+		}
+
+		theater := &response.Theaters[index]
+		date := timeSlot.StartTime.UTC().Format(time.DateOnly)
+
+		if len(theater.Days) == 0 || theater.Days[len(theater.Days)-1].Date != date {
+			theater.Days = append(theater.Days, ShowtimeDayResponse{
+				Date:      date,
+				Showtimes: []ShowtimeResponse{},
+			})
+		}
+
+		day := &theater.Days[len(theater.Days)-1]
+		day.Showtimes = append(day.Showtimes, ShowtimeResponse{
+			ID:        timeSlot.ID,
+			StartTime: timeSlot.StartTime,
+			EndTime:   timeSlot.EndTime,
+			RoomID:    timeSlot.RoomID,
+			RoomName:  timeSlot.Room.Name,
+		})
+	}
+
+	return response
+}
+
+func getTimeSlotTheaterFilter(c *gin.Context) (request.Filter, error) {
+	theaterID := c.Query("theater_id")
+	if theaterID == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(theaterID)
+	if err != nil {
+		return nil, middleware.NewBadRequestError(fmt.Sprintf("Invalid theater_id: %s", theaterID))
+	}
+
+	return models.TimeSlotTheaterFilter{TheaterID: id}, nil
+}
+
+// MovieShowtimes
+//
+//	@Id				MovieShowtimes
+//	@Summary		List movie showtimes
+//	@Description	List upcoming showtimes of the movie across all theaters, grouped by theater and day
+//	@Tags			movies
+//	@Accept			json
+//	@Produce		json
+//	@Param			movieID			path		string	true	"Movie ID"						Format(uuid)
+//	@Param			date			query		string	false	"Filter by date (YYYY-MM-DD)"	Format(date)
+//	@Param			from			query		string	false	"Filter by first date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			to				query		string	false	"Filter by last date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			start_after		query		string	false	"Filter by start time of day (HH:MM, UTC), inclusive"
+//	@Param			start_before	query		string	false	"Filter by start time of day (HH:MM, UTC), exclusive"
+//	@Param			weekday			query		string	false	"Comma separated weekdays (monday, ..., sunday)"
+//	@Param			theater_id		query		string	false	"Filter by theater"	Format(uuid)
+//	@Success		200				{object}	MovieShowtimesResponse
+//	@Failure		400				{object}	middleware.HttpError
+//	@Failure		404				{object}	middleware.HttpError
+//	@Failure		500				{object}	middleware.HttpError
+//	@Router			/movies/{movieID}/showtimes [get]
+func MovieShowtimes(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	movie := GetContextMovie(c)
+
+	scheduleFilters, err := getTimeSlotScheduleFilters(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	theaterFilter, err := getTimeSlotTheaterFilter(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	filters := request.NewFilterOptions(append(scheduleFilters, theaterFilter)...)
+
+	timeSlots, err := models.GetMovieShowtimes(tx, movie.ID, time.Now(), filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newMovieShowtimesResponse(movie, timeSlots))
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PRPO-skupina-02/common/database"
+	"github.com/PRPO-skupina-02/common/xtesting"
+	"github.com/PRPO-skupina-02/spored/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovieShowtimes(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name   string
+		status int
+		id     string
+		params string
+	}{
+		{
+			name:   "ok",
+			status: http.StatusOK,
+			id:     "afddb478-e23e-11f0-92e2-3be5b904bf71",
+		},
+		{
+			name:   "ok-filter-date-range",
+			status: http.StatusOK,
+			id:     "afddb478-e23e-11f0-92e2-3be5b904bf71",
+			params: "?from=2099-06-07",
+		},
+		{
+			name:   "ok-filter-theater",
+			status: http.StatusOK,
+			id:     "afddb478-e23e-11f0-92e2-3be5b904bf71",
+			params: "?theater_id=fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+		},
+		{
+			name:   "ok-no-showtimes",
+			status: http.StatusOK,
+			id:     "27e36818-e240-11f0-bb29-538173c01e43",
+		},
+		{
+			name:   "invalid-date-range",
+			status: http.StatusBadRequest,
+			id:     "afddb478-e23e-11f0-92e2-3be5b904bf71",
+			params: "?to=06-06-2099",
+		},
+		{
+			name:   "invalid-theater-filter",
+			status: http.StatusBadRequest,
+			id:     "afddb478-e23e-11f0-92e2-3be5b904bf71",
+			params: "?theater_id=000",
+		},
+		{
+			name:   "invalid-id",
+			status: http.StatusNotFound,
+			id:     "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:   "nil-id",
+			status: http.StatusBadRequest,
+			id:     "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			name:   "malformed-id",
+			status: http.StatusBadRequest,
+			id:     "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/movies/%s/showtimes%s", testCase.id, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
@@ -12,21 +12,39 @@ import (
 )
 
 type TheaterResponse struct {
-	ID        uuid.UUID `json:"id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	Name      string    `json:"name"`
+	ID                  uuid.UUID `json:"id"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+	Name                string    `json:"name"`
+	ScheduleHorizonDays *int      `json:"schedule_horizon_days"`
+
+	Rooms *[]RoomResponse `json:"rooms,omitempty"`
 }
 
 func newTheaterResponse(theater models.Theater) TheaterResponse {
 	return TheaterResponse{
-		ID:        theater.ID,
-		CreatedAt: theater.CreatedAt,
-		UpdatedAt: theater.UpdatedAt,
-		Name:      theater.Name,
+		ID:                  theater.ID,
+		CreatedAt:           theater.CreatedAt,
+		UpdatedAt:           theater.UpdatedAt,
+		Name:                theater.Name,
+		ScheduleHorizonDays: theater.ScheduleHorizonDays,
 	}
 }
 
+func newExpandedTheaterResponse(theater models.Theater, expand expandOptions) TheaterResponse {
+	response := newTheaterResponse(theater)
+
+	if expand["rooms"] {
+		rooms := []RoomResponse{}
+		for _, room := range theater.Rooms {
+			rooms = append(rooms, newRoomResponse(room))
+		}
+		response.Rooms = &rooms
+	}
+
+	return response
+}
+
 // TheatersList
 //
 //	@Id				TheatersList
@@ -38,6 +56,7 @@ func newTheaterResponse(theater models.Theater) TheaterResponse {
 //	@Param			limit	query		int		false	"Limit the number of responses"	Default(10)
 //	@Param			offset	query		int		false	"Offset the first response"		Default(0)
 //	@Param			sort	query		string	false	"Sort results"
+//	@Param			expand	query		string	false	"Comma separated related resources to expand (rooms)"
 //	@Success		200		{object}	request.PaginatedResponse{data=[]TheaterResponse}
 //	@Failure		400		{object}	middleware.HttpError
 //	@Failure		404		{object}	middleware.HttpError
@@ -48,7 +67,13 @@ func TheatersList(c *gin.Context) {
 	pagination := request.GetNormalizedPaginationArgs(c)
 	sort := request.GetSortOptions(c)
 
-	theaters, total, err := models.GetTheaters(tx, pagination, sort)
+	expand, preloads, err := getExpandOptions(c, theaterExpandPreloads)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	theaters, total, err := models.GetTheaters(tx, pagination, sort, preloads...)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -57,14 +82,15 @@ func TheatersList(c *gin.Context) {
 	response := []TheaterResponse{}
 
 	for _, theater := range theaters {
-		response = append(response, newTheaterResponse(theater))
+		response = append(response, newExpandedTheaterResponse(theater, expand))
 	}
 
 	request.RenderPaginatedResponse(c, response, total)
 }
 
 type TheaterRequest struct {
-	Name string `json:"name" binding:"required,min=3"`
+	Name                string `json:"name" binding:"required,min=3"`
+	ScheduleHorizonDays *int   `json:"schedule_horizon_days" binding:"omitempty,min=1,max=60"`
 }
 
 // TheatersCreate
@@ -92,8 +118,9 @@ func TheatersCreate(c *gin.Context) {
 	}
 
 	theater := models.Theater{
-		ID:   uuid.New(),
-		Name: req.Name,
+		ID:                  uuid.New(),
+		Name:                req.Name,
+		ScheduleHorizonDays: req.ScheduleHorizonDays,
 	}
 
 	err = theater.Create(tx)
@@ -114,14 +141,31 @@ func TheatersCreate(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			theaterID	path		string	true	"Theater ID"	Format(uuid)
+//	@Param			expand		query		string	false	"Comma separated related resources to expand (rooms)"
 //	@Success		200			{object}	TheaterResponse
 //	@Failure		400			{object}	middleware.HttpError
 //	@Failure		404			{object}	middleware.HttpError
 //	@Failure		500			{object}	middleware.HttpError
 //	@Router			/theaters/{theaterID} [get]
 func TheatersShow(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
 	theater := GetContextTheater(c)
-	c.JSON(http.StatusOK, newTheaterResponse(theater))
+
+	expand, preloads, err := getExpandOptions(c, theaterExpandPreloads)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if len(preloads) != 0 {
+		theater, err = models.GetTheater(tx, theater.ID, preloads...)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, newExpandedTheaterResponse(theater, expand))
 }
 
 // TheatersUpdate
@@ -151,6 +195,7 @@ func TheatersUpdate(c *gin.Context) {
 	}
 
 	theater.Name = req.Name
+	theater.ScheduleHorizonDays = req.ScheduleHorizonDays
 
 	err = theater.Save(tx)
 	if err != nil {
@@ -165,21 +210,29 @@ func TheatersUpdate(c *gin.Context) {
 //
 //	@Id				TheatersDelete
 //	@Summary		Delete theater
-//	@Description	Delete theater
+//	@Description	Delete theater together with its rooms and cancel their future time slots. Theaters with reservations for future time slots are not deleted and the reserved time slots are listed in a conflict, unless forced
 //	@Tags			theaters
 //	@Accept			json
 //	@Produce		json
 //	@Param			theaterID	path	string	true	"Theater ID"	Format(uuid)
+//	@Param			force		query	bool	false	"Delete the theater even if future time slots have reservations"
 //	@Success		204
 //	@Failure		400	{object}	middleware.HttpError
 //	@Failure		404	{object}	middleware.HttpError
+//	@Failure		409	{object}	middleware.HttpError
 //	@Failure		500	{object}	middleware.HttpError
 //	@Router			/theaters/{theaterID} [delete]
 func TheatersDelete(c *gin.Context) {
 	tx := middleware.GetContextTransaction(c)
 	theater := GetContextTheater(c)
 
-	err := models.DeleteTheater(tx, theater.ID)
+	force, err := getForce(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = models.DeleteTheater(tx, theater.ID, force)
 	if err != nil {
 		_ = c.Error(err)
 		return
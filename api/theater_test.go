@@ -42,6 +42,16 @@ func TestTheatersList(t *testing.T) {
 			status: http.StatusOK,
 			params: "?limit=2&offset=1&sort=updated_at",
 		},
+		{
+			name:   "ok-expand",
+			status: http.StatusOK,
+			params: "?expand=rooms",
+		},
+		{
+			name:   "invalid-expand",
+			status: http.StatusBadRequest,
+			params: "?expand=theater",
+		},
 	}
 
 	for _, testCase := range tests {
@@ -172,6 +182,9 @@ func TestTheatersUpdate(t *testing.T) {
 	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
 	r := TestingRouter(t, db)
 
+	horizon := 14
+	invalidHorizon := 0
+
 	tests := []struct {
 		name   string
 		body   TheaterRequest
@@ -186,6 +199,24 @@ func TestTheatersUpdate(t *testing.T) {
 			status: http.StatusOK,
 			id:     "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
 		},
+		{
+			name: "ok-schedule-horizon",
+			body: TheaterRequest{
+				Name:                "NewTheater",
+				ScheduleHorizonDays: &horizon,
+			},
+			status: http.StatusOK,
+			id:     "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+		},
+		{
+			name: "invalid-schedule-horizon",
+			body: TheaterRequest{
+				Name:                "NewTheater",
+				ScheduleHorizonDays: &invalidHorizon,
+			},
+			status: http.StatusBadRequest,
+			id:     "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+		},
 		{
 			name: "short-name",
 			body: TheaterRequest{
@@ -259,12 +290,30 @@ func TestTheatersDelete(t *testing.T) {
 		name   string
 		status int
 		id     string
+		params string
 	}{
 		{
 			name:   "ok",
 			status: http.StatusNoContent,
 			id:     "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
 		},
+		{
+			name:   "ok-force",
+			status: http.StatusNoContent,
+			id:     "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			params: "?force=true",
+		},
+		{
+			name:   "reserved-timeslots",
+			status: http.StatusConflict,
+			id:     "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+		},
+		{
+			name:   "invalid-force",
+			status: http.StatusBadRequest,
+			id:     "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			params: "?force=maybe",
+		},
 		{
 			name:   "invalid-id",
 			status: http.StatusNotFound,
@@ -287,7 +336,7 @@ func TestTheatersDelete(t *testing.T) {
 			err := fixtures.Load()
 			assert.NoError(t, err)
 
-			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s", testCase.id)
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s%s", testCase.id, testCase.params)
 
 			req := xtesting.NewTestingRequest(t, targetURL, http.MethodDelete, nil)
 			w := httptest.NewRecorder()
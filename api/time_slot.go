@@ -1,7 +1,10 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/PRPO-skupina-02/common/middleware"
@@ -12,25 +15,333 @@ import (
 )
 
 type TimeSlotResponse struct {
-	ID        uuid.UUID `json:"id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	StartTime time.Time `json:"start_time"`
-	EndTime   time.Time `json:"end_time"`
-	RoomID    uuid.UUID `json:"room_id"`
-	MovieID   uuid.UUID `json:"movie_id"`
+	ID                 uuid.UUID             `json:"id"`
+	CreatedAt          time.Time             `json:"created_at"`
+	UpdatedAt          time.Time             `json:"updated_at"`
+	StartTime          time.Time             `json:"start_time"`
+	EndTime            time.Time             `json:"end_time"`
+	Locked             bool                  `json:"locked"`
+	Status             models.TimeSlotStatus `json:"status"`
+	CancellationReason *string               `json:"cancellation_reason"`
+	CancelledAt        *time.Time            `json:"cancelled_at"`
+	RoomID             uuid.UUID             `json:"room_id"`
+	MovieID            uuid.UUID             `json:"movie_id"`
+	// Resolved ticket prices, omitted when the time slot is not priced
+	Prices *PriceTableResponse `json:"prices,omitempty"`
+	// Seats of the time slot, included in listings
+	Occupancy *OccupancyResponse `json:"occupancy,omitempty"`
+
+	Movie   *MovieResponse   `json:"movie,omitempty"`
+	Room    *RoomResponse    `json:"room,omitempty"`
+	Theater *TheaterResponse `json:"theater,omitempty"`
 }
 
 func newTimeSlotResponse(timeSlot models.TimeSlot) TimeSlotResponse {
 	return TimeSlotResponse{
-		ID:        timeSlot.ID,
-		CreatedAt: timeSlot.CreatedAt,
-		UpdatedAt: timeSlot.UpdatedAt,
-		StartTime: timeSlot.StartTime,
-		EndTime:   timeSlot.EndTime,
-		RoomID:    timeSlot.RoomID,
-		MovieID:   timeSlot.MovieID,
+		ID:                 timeSlot.ID,
+		CreatedAt:          timeSlot.CreatedAt,
+		UpdatedAt:          timeSlot.UpdatedAt,
+		StartTime:          timeSlot.StartTime,
+		EndTime:            timeSlot.EndTime,
+		Locked:             timeSlot.Locked,
+		Status:             timeSlot.Status,
+		CancellationReason: timeSlot.CancellationReason,
+		CancelledAt:        timeSlot.CancelledAt,
+		RoomID:             timeSlot.RoomID,
+		MovieID:            timeSlot.MovieID,
+	}
+}
+
+// newExpandedTimeSlotResponse nests the expanded related resources, which
+// have to be preloaded
+func newExpandedTimeSlotResponse(timeSlot models.TimeSlot, expand expandOptions) TimeSlotResponse {
+	response := newTimeSlotResponse(timeSlot)
+
+	if expand["movie"] {
+		movie := newMovieResponse(timeSlot.Movie)
+		response.Movie = &movie
+	}
+	if expand["room"] {
+		room := newRoomResponse(timeSlot.Room)
+		response.Room = &room
+	}
+	if expand["theater"] {
+		theater := newTheaterResponse(timeSlot.Room.Theater)
+		response.Theater = &theater
+	}
+
+	return response
+}
+
+// withPrices adds the resolved price table of the time slot, if there is one
+func (r TimeSlotResponse) withPrices(tables map[uuid.UUID]models.PriceTable) TimeSlotResponse {
+	if table, ok := tables[r.ID]; ok {
+		prices := newPriceTableResponse(table)
+		r.Prices = &prices
+	}
+	return r
+}
+
+type OccupancyResponse struct {
+	// Number of usable seats of the room
+	Capacity  int  `json:"capacity"`
+	Sold      int  `json:"sold"`
+	Held      int  `json:"held"`
+	Available int  `json:"available"`
+	SoldOut   bool `json:"sold_out"`
+}
+
+func newOccupancyResponse(occupancy models.Occupancy) OccupancyResponse {
+	return OccupancyResponse{
+		Capacity:  occupancy.Capacity,
+		Sold:      occupancy.Sold,
+		Held:      occupancy.Held,
+		Available: occupancy.Available(),
+		SoldOut:   occupancy.SoldOut(),
+	}
+}
+
+// withOccupancy adds the resolved occupancy of the time slot
+func (r TimeSlotResponse) withOccupancy(occupancies map[uuid.UUID]models.Occupancy) TimeSlotResponse {
+	if occupancy, ok := occupancies[r.ID]; ok {
+		response := newOccupancyResponse(occupancy)
+		r.Occupancy = &response
+	}
+	return r
+}
+
+func newTimeSlotResponses(timeSlots []models.TimeSlot) []TimeSlotResponse {
+	response := []TimeSlotResponse{}
+	for _, timeSlot := range timeSlots {
+		response = append(response, newTimeSlotResponse(timeSlot))
+	}
+	return response
+}
+
+type MovieSummaryResponse struct {
+	ID            uuid.UUID `json:"id"`
+	Title         string    `json:"name"`
+	ImageURL      string    `json:"image_url"`
+	Rating        float64   `json:"rating"`
+	LengthMinutes int       `json:"length_minutes"`
+}
+
+func newMovieSummaryResponse(movie models.Movie) MovieSummaryResponse {
+	return MovieSummaryResponse{
+		ID:            movie.ID,
+		Title:         movie.Title,
+		ImageURL:      movie.ImageURL,
+		Rating:        movie.Rating,
+		LengthMinutes: movie.LengthMinutes,
+	}
+}
+
+type TheaterTimeSlotResponse struct {
+	TimeSlotResponse
+	RoomName string               `json:"room_name"`
+	Movie    MovieSummaryResponse `json:"movie"`
+}
+
+func newTheaterTimeSlotResponse(timeSlot models.TimeSlot, expand expandOptions) TheaterTimeSlotResponse {
+	return TheaterTimeSlotResponse{
+		TimeSlotResponse: newExpandedTimeSlotResponse(timeSlot, expand),
+		RoomName:         timeSlot.Room.Name,
+		Movie:            newMovieSummaryResponse(timeSlot.Movie),
+	}
+}
+
+// parseTimeSlotBound parses either an RFC 3339 instant or a date, a date used
+// as the upper bound includes the whole day
+func parseTimeSlotBound(name, value string, upper bool) (time.Time, error) {
+	instant, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return instant, nil
+	}
+
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, middleware.NewBadRequestError(fmt.Sprintf("Invalid %s date: %s", name, value))
+	}
+
+	if upper {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date, nil
+}
+
+// getTimeSlotDateFilter parses the date query parameter, unlike
+// request.GetDateFilter malformed dates are rejected
+func getTimeSlotDateFilter(c *gin.Context) (request.Filter, error) {
+	query := c.Query("date")
+	if query == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(time.DateOnly, query)
+	if err != nil {
+		return nil, middleware.NewBadRequestError(fmt.Sprintf("Invalid date: %s", query))
+	}
+
+	to := date.AddDate(0, 0, 1)
+	return models.TimeRangeFilter{Column: "time_slots.start_time", From: &date, To: &to}, nil
+}
+
+// getTimeSlotDateRangeFilter parses the from and to bounds, which are either
+// instants or inclusive dates
+func getTimeSlotDateRangeFilter(c *gin.Context) (request.Filter, error) {
+	filter := models.TimeRangeFilter{Column: "time_slots.start_time"}
+
+	if from := c.Query("from"); from != "" {
+		instant, err := parseTimeSlotBound("from", from, false)
+		if err != nil {
+			return nil, err
+		}
+		filter.From = &instant
 	}
+
+	if to := c.Query("to"); to != "" {
+		instant, err := parseTimeSlotBound("to", to, true)
+		if err != nil {
+			return nil, err
+		}
+		filter.To = &instant
+	}
+
+	return filter, nil
+}
+
+func getTimeSlotTimeOfDayFilter(c *gin.Context) (request.Filter, error) {
+	filter := models.TimeOfDayFilter{}
+
+	after, err := parseTimeOfDay(c, "start_after")
+	if err != nil {
+		return nil, err
+	}
+	filter.After = after
+
+	before, err := parseTimeOfDay(c, "start_before")
+	if err != nil {
+		return nil, err
+	}
+	filter.Before = before
+
+	return filter, nil
+}
+
+func parseTimeOfDay(c *gin.Context, name string) (*time.Time, error) {
+	query := c.Query(name)
+	if query == "" {
+		return nil, nil
+	}
+
+	timeOfDay, err := time.Parse("15:04", query)
+	if err != nil {
+		return nil, middleware.NewBadRequestError(fmt.Sprintf("Invalid %s time: %s", name, query))
+	}
+
+	return &timeOfDay, nil
+}
+
+// getTimeSlotWeekdayFilter parses a comma separated list of english weekday
+// names from the weekday query parameter
+func getTimeSlotWeekdayFilter(c *gin.Context) (request.Filter, error) {
+	query := c.Query("weekday")
+	if query == "" {
+		return nil, nil
+	}
+
+	filter := models.TimeSlotWeekdayFilter{}
+
+	for _, name := range strings.Split(query, ",") {
+		weekday, ok := parseWeekday(name)
+		if !ok {
+			return nil, middleware.NewBadRequestError(fmt.Sprintf("Invalid weekday: %s", name))
+		}
+		filter.Weekdays = append(filter.Weekdays, weekday)
+	}
+
+	return filter, nil
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), name) {
+			return weekday, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// getTimeSlotScheduleFilters parses the date, range, time of day and weekday
+// filters shared by all time slot listings
+func getTimeSlotScheduleFilters(c *gin.Context) ([]request.Filter, error) {
+	filters := []request.Filter{}
+
+	for _, parse := range []func(*gin.Context) (request.Filter, error){
+		getTimeSlotDateFilter,
+		getTimeSlotDateRangeFilter,
+		getTimeSlotTimeOfDayFilter,
+		getTimeSlotWeekdayFilter,
+	} {
+		filter, err := parse(c)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+func getTimeSlotMovieFilter(c *gin.Context) (request.Filter, error) {
+	movieID := c.Query("movie_id")
+	if movieID == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(movieID)
+	if err != nil {
+		return nil, middleware.NewBadRequestError(fmt.Sprintf("Invalid movie_id: %s", movieID))
+	}
+
+	return models.TimeSlotMovieFilter{MovieID: id}, nil
+}
+
+// getTimeSlotStatusFilter parses a comma separated list of statuses from the
+// status query parameter, cancelled time slots are excluded when it is empty
+func getTimeSlotStatusFilter(c *gin.Context) (request.Filter, error) {
+	filter := models.TimeSlotStatusFilter{}
+
+	query := c.Query("status")
+	if query == "" {
+		return filter, nil
+	}
+
+	for _, status := range strings.Split(query, ",") {
+		switch models.TimeSlotStatus(status) {
+		case models.ScheduledTimeSlot, models.CancelledTimeSlot, models.CompletedTimeSlot:
+			filter.Statuses = append(filter.Statuses, models.TimeSlotStatus(status))
+		default:
+			return nil, middleware.NewBadRequestError(fmt.Sprintf("Invalid time slot status: %s", status))
+		}
+	}
+
+	return filter, nil
+}
+
+// getTimeSlotSoldOutFilter parses the sold_out query parameter
+func getTimeSlotSoldOutFilter(c *gin.Context) (request.Filter, error) {
+	query := c.Query("sold_out")
+	if query == "" {
+		return nil, nil
+	}
+
+	soldOut, err := strconv.ParseBool(query)
+	if err != nil {
+		return nil, middleware.NewBadRequestError(fmt.Sprintf("Invalid sold_out: %s", query))
+	}
+
+	return models.TimeSlotSoldOutFilter{SoldOut: soldOut, Now: time.Now()}, nil
 }
 
 // TimeSlotsList
@@ -41,24 +352,55 @@ func newTimeSlotResponse(timeSlot models.TimeSlot) TimeSlotResponse {
 //	@Tags			timeslots
 //	@Accept			json
 //	@Produce		json
-//	@Param			theaterID	path		string	true	"Theater ID"					Format(uuid)
-//	@Param			roomID		path		string	true	"Room ID"						Format(uuid)
-//	@Param			limit		query		int		false	"Limit the number of responses"	Default(10)
-//	@Param			offset		query		int		false	"Offset the first response"		Default(0)
-//	@Param			sort		query		string	false	"Sort results"
-//	@Param			date		query		string	false	"Filter by date (YYYY-MM-DD)"	Format(date)
-//	@Success		200			{object}	request.PaginatedResponse{data=[]TimeSlotResponse}
-//	@Failure		400			{object}	middleware.HttpError
-//	@Failure		404			{object}	middleware.HttpError
-//	@Failure		500			{object}	middleware.HttpError
+//	@Param			theaterID		path		string	true	"Theater ID"					Format(uuid)
+//	@Param			roomID			path		string	true	"Room ID"						Format(uuid)
+//	@Param			limit			query		int		false	"Limit the number of responses"	Default(10)
+//	@Param			offset			query		int		false	"Offset the first response"		Default(0)
+//	@Param			sort			query		string	false	"Sort results"
+//	@Param			date			query		string	false	"Filter by date (YYYY-MM-DD)"	Format(date)
+//	@Param			from			query		string	false	"Filter by first date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			to				query		string	false	"Filter by last date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			start_after		query		string	false	"Filter by start time of day (HH:MM, UTC), inclusive"
+//	@Param			start_before	query		string	false	"Filter by start time of day (HH:MM, UTC), exclusive"
+//	@Param			weekday			query		string	false	"Comma separated weekdays (monday, ..., sunday)"
+//	@Param			status			query		string	false	"Comma separated statuses (SCHEDULED, CANCELLED, COMPLETED), cancelled time slots are excluded by default"
+//	@Param			sold_out		query		bool	false	"Filter by whether no seats are available"
+//	@Param			expand			query		string	false	"Comma separated related resources to expand (movie, room, theater)"
+//	@Success		200				{object}	request.PaginatedResponse{data=[]TimeSlotResponse}
+//	@Failure		400				{object}	middleware.HttpError
+//	@Failure		404				{object}	middleware.HttpError
+//	@Failure		500				{object}	middleware.HttpError
 //	@Router			/theaters/{theaterID}/rooms/{roomID}/timeslots [get]
 func TimeSlotsList(c *gin.Context) {
 	tx := middleware.GetContextTransaction(c)
 	theater := GetContextTheater(c)
 	pagination := request.GetNormalizedPaginationArgs(c)
 	sort := request.GetSortOptions(c)
-	filter := request.GetDateFilter(c, "start_time")
-	filters := request.NewFilterOptions(filter)
+
+	scheduleFilters, err := getTimeSlotScheduleFilters(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	statusFilter, err := getTimeSlotStatusFilter(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	soldOutFilter, err := getTimeSlotSoldOutFilter(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	filters := request.NewFilterOptions(append(scheduleFilters, statusFilter, soldOutFilter)...)
+
+	expand, preloads, err := getExpandOptions(c, timeSlotExpandPreloads)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 
 	id, err := request.GetUUIDParam(c, "roomID")
 	if err != nil {
@@ -72,7 +414,19 @@ func TimeSlotsList(c *gin.Context) {
 		return
 	}
 
-	timeSlots, total, err := models.GetRoomTimeSlots(tx, room.ID, pagination, sort, filters)
+	timeSlots, total, err := models.GetRoomTimeSlots(tx, room.ID, pagination, sort, filters, preloads...)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	tables, err := models.ResolveTimeSlotPrices(tx, timeSlots)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	occupancies, err := models.ResolveTimeSlotOccupancies(tx, timeSlots, time.Now())
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -81,7 +435,7 @@ func TimeSlotsList(c *gin.Context) {
 	response := []TimeSlotResponse{}
 
 	for _, timeSlot := range timeSlots {
-		response = append(response, newTimeSlotResponse(timeSlot))
+		response = append(response, newExpandedTimeSlotResponse(timeSlot, expand).withPrices(tables).withOccupancy(occupancies))
 	}
 
 	request.RenderPaginatedResponse(c, response, total)
@@ -98,6 +452,7 @@ func TimeSlotsList(c *gin.Context) {
 //	@Param			theaterID	path		string	true	"Theater ID"	Format(uuid)
 //	@Param			roomID		path		string	true	"Room ID"		Format(uuid)
 //	@Param			timeSlotID	path		string	true	"TimeSlot ID"	Format(uuid)
+//	@Param			expand		query		string	false	"Comma separated related resources to expand (movie, room, theater)"
 //	@Success		200			{object}	TimeSlotResponse
 //	@Failure		400			{object}	middleware.HttpError
 //	@Failure		404			{object}	middleware.HttpError
@@ -116,7 +471,51 @@ func TimeSlotsShow(c *gin.Context) {
 		return
 	}
 
-	timeSlot, err := models.GetTimeSlot(tx, roomID, timeSlotID)
+	expand, preloads, err := getExpandOptions(c, timeSlotExpandPreloads)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	timeSlot, err := models.GetTimeSlot(tx, roomID, timeSlotID, preloads...)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	tables, err := models.ResolveTimeSlotPrices(tx, []models.TimeSlot{timeSlot})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	occupancies, err := models.ResolveTimeSlotOccupancies(tx, []models.TimeSlot{timeSlot}, time.Now())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newExpandedTimeSlotResponse(timeSlot, expand).withPrices(tables).withOccupancy(occupancies))
+}
+
+func setTimeSlotLocked(c *gin.Context, locked bool) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	timeSlotID, err := request.GetUUIDParam(c, "timeSlotID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	timeSlot, err := models.GetTimeSlot(tx, room.ID, timeSlotID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	timeSlot.Locked = locked
+
+	err = timeSlot.Save(tx)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -124,3 +523,301 @@ func TimeSlotsShow(c *gin.Context) {
 
 	c.JSON(http.StatusOK, newTimeSlotResponse(timeSlot))
 }
+
+// TimeSlotsLock
+//
+//	@Id				TimeSlotsLock
+//	@Summary		Lock time slot
+//	@Description	Lock time slot, so that it is never moved or cancelled by automatic rescheduling
+//	@Tags			timeslots
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string	true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path		string	true	"Room ID"		Format(uuid)
+//	@Param			timeSlotID	path		string	true	"TimeSlot ID"	Format(uuid)
+//	@Success		200			{object}	TimeSlotResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/lock [post]
+func TimeSlotsLock(c *gin.Context) {
+	setTimeSlotLocked(c, true)
+}
+
+// TimeSlotsUnlock
+//
+//	@Id				TimeSlotsUnlock
+//	@Summary		Unlock time slot
+//	@Description	Unlock time slot
+//	@Tags			timeslots
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string	true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path		string	true	"Room ID"		Format(uuid)
+//	@Param			timeSlotID	path		string	true	"TimeSlot ID"	Format(uuid)
+//	@Success		200			{object}	TimeSlotResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/unlock [post]
+func TimeSlotsUnlock(c *gin.Context) {
+	setTimeSlotLocked(c, false)
+}
+
+type TimeSlotCancelRequest struct {
+	Reason string `json:"reason" binding:"required,min=3"`
+}
+
+// TimeSlotsCancel
+//
+//	@Id				TimeSlotsCancel
+//	@Summary		Cancel time slot
+//	@Description	Cancel a scheduled time slot, the time slot is kept with its cancellation reason. A time slot with reservations is only cancelled with force, otherwise the request fails with 409
+//	@Tags			timeslots
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string					true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path		string					true	"Room ID"		Format(uuid)
+//	@Param			timeSlotID	path		string					true	"TimeSlot ID"	Format(uuid)
+//	@Param			force		query		bool					false	"Cancel the time slot even if it has reservations"
+//	@Param			request		body		TimeSlotCancelRequest	true	"request body"
+//	@Success		200			{object}	TimeSlotResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		409			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/timeslots/{timeSlotID}/cancel [post]
+func TimeSlotsCancel(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+	timeSlotID, err := request.GetUUIDParam(c, "timeSlotID")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	force, err := getForce(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req TimeSlotCancelRequest
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	timeSlot, err := models.GetTimeSlot(tx, room.ID, timeSlotID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if timeSlot.Status != models.ScheduledTimeSlot {
+		_ = c.Error(middleware.NewBadRequestError("Only scheduled time slots can be cancelled"))
+		return
+	}
+
+	now := time.Now()
+	if !timeSlot.StartTime.After(now) {
+		_ = c.Error(middleware.NewBadRequestError("Only time slots that have not started yet can be cancelled"))
+		return
+	}
+
+	err = timeSlot.PrepareCancel(tx, force, now)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = timeSlot.Cancel(tx, req.Reason, now)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTimeSlotResponse(timeSlot))
+}
+
+type TimeSlotsShiftRequest struct {
+	After    string `json:"after" binding:"required,datetime=2006-01-02T15:04:05Z07:00" format:"date-time"`
+	Minutes  int    `json:"minutes" binding:"required,min=1,max=720"`
+	Overflow string `json:"overflow" binding:"omitempty,oneof=DROP FLAG" enums:"DROP,FLAG"`
+}
+
+type TimeSlotsShiftResponse struct {
+	Moved   []TimeSlotResponse `json:"moved"`
+	Dropped []TimeSlotResponse `json:"dropped"`
+	Flagged []TimeSlotResponse `json:"flagged"`
+}
+
+func newTimeSlotsShiftResponse(result models.TimeSlotShiftResult) TimeSlotsShiftResponse {
+	response := TimeSlotsShiftResponse{
+		Moved:   []TimeSlotResponse{},
+		Dropped: []TimeSlotResponse{},
+		Flagged: []TimeSlotResponse{},
+	}
+
+	for _, timeSlot := range result.Moved {
+		response.Moved = append(response.Moved, newTimeSlotResponse(timeSlot))
+	}
+	for _, timeSlot := range result.Dropped {
+		response.Dropped = append(response.Dropped, newTimeSlotResponse(timeSlot))
+	}
+	for _, timeSlot := range result.Flagged {
+		response.Flagged = append(response.Flagged, newTimeSlotResponse(timeSlot))
+	}
+
+	return response
+}
+
+// TimeSlotsShift
+//
+//	@Id				TimeSlotsShift
+//	@Summary		Shift time slots
+//	@Description	Push back every scheduled time slot of the room that starts after the given instant and before closing time of that day by the given number of minutes, keeping the breaks between them. Time slots that would end after closing time are cancelled with overflow DROP, or moved anyway and reported with overflow FLAG (default). Dropping time slots with reservations fails with 409 unless forced. Moving a time slot onto another one fails with 409
+//	@Tags			timeslots
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID	path		string					true	"Theater ID"	Format(uuid)
+//	@Param			roomID		path		string					true	"Room ID"		Format(uuid)
+//	@Param			force		query		bool					false	"Drop overflowing time slots even if they have reservations"
+//	@Param			request		body		TimeSlotsShiftRequest	true	"request body"
+//	@Success		200			{object}	TimeSlotsShiftResponse
+//	@Failure		400			{object}	middleware.HttpError
+//	@Failure		404			{object}	middleware.HttpError
+//	@Failure		409			{object}	middleware.HttpError
+//	@Failure		500			{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/rooms/{roomID}/timeslots/shift [post]
+func TimeSlotsShift(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	room := GetContextRoom(c)
+
+	force, err := getForce(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req TimeSlotsShiftRequest
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	after, err := time.Parse(time.RFC3339, req.After)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	overflow := models.FlagOverflow
+	if req.Overflow != "" {
+		overflow = models.TimeSlotShiftOverflow(req.Overflow)
+	}
+
+	result, err := room.ShiftTimeSlots(tx, after, time.Duration(req.Minutes)*time.Minute, overflow, force, time.Now())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTimeSlotsShiftResponse(result))
+}
+
+// TheaterTimeSlotsList
+//
+//	@Id				TheaterTimeSlotsList
+//	@Summary		List theater time slots
+//	@Description	List time slots of all rooms in the theater, ordered by start time
+//	@Tags			timeslots
+//	@Accept			json
+//	@Produce		json
+//	@Param			theaterID		path		string	true	"Theater ID"					Format(uuid)
+//	@Param			limit			query		int		false	"Limit the number of responses"	Default(10)
+//	@Param			offset			query		int		false	"Offset the first response"		Default(0)
+//	@Param			sort			query		string	false	"Sort results"
+//	@Param			date			query		string	false	"Filter by date (YYYY-MM-DD)"	Format(date)
+//	@Param			from			query		string	false	"Filter by first date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			to				query		string	false	"Filter by last date (YYYY-MM-DD) or instant (RFC 3339)"
+//	@Param			start_after		query		string	false	"Filter by start time of day (HH:MM, UTC), inclusive"
+//	@Param			start_before	query		string	false	"Filter by start time of day (HH:MM, UTC), exclusive"
+//	@Param			weekday			query		string	false	"Comma separated weekdays (monday, ..., sunday)"
+//	@Param			movie_id		query		string	false	"Filter by movie"	Format(uuid)
+//	@Param			status			query		string	false	"Comma separated statuses (SCHEDULED, CANCELLED, COMPLETED), cancelled time slots are excluded by default"
+//	@Param			sold_out		query		bool	false	"Filter by whether no seats are available"
+//	@Param			expand			query		string	false	"Comma separated related resources to expand (room, theater)"
+//	@Success		200				{object}	request.PaginatedResponse{data=[]TheaterTimeSlotResponse}
+//	@Failure		400				{object}	middleware.HttpError
+//	@Failure		404				{object}	middleware.HttpError
+//	@Failure		500				{object}	middleware.HttpError
+//	@Router			/theaters/{theaterID}/timeslots [get]
+func TheaterTimeSlotsList(c *gin.Context) {
+	tx := middleware.GetContextTransaction(c)
+	theater := GetContextTheater(c)
+	pagination := request.GetNormalizedPaginationArgs(c)
+	sort := request.GetSortOptions(c)
+
+	scheduleFilters, err := getTimeSlotScheduleFilters(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	movieFilter, err := getTimeSlotMovieFilter(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	statusFilter, err := getTimeSlotStatusFilter(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	soldOutFilter, err := getTimeSlotSoldOutFilter(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	filters := request.NewFilterOptions(append(scheduleFilters, movieFilter, statusFilter, soldOutFilter)...)
+
+	expand, preloads, err := getExpandOptions(c, theaterTimeSlotExpandPreloads)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	timeSlots, total, err := models.GetTheaterTimeSlots(tx, theater.ID, pagination, sort, filters, preloads...)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	tables, err := models.ResolveTimeSlotPrices(tx, timeSlots)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	occupancies, err := models.ResolveTimeSlotOccupancies(tx, timeSlots, time.Now())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := []TheaterTimeSlotResponse{}
+
+	for _, timeSlot := range timeSlots {
+		timeSlotResponse := newTheaterTimeSlotResponse(timeSlot, expand)
+		timeSlotResponse.TimeSlotResponse = timeSlotResponse.withPrices(tables).withOccupancy(occupancies)
+		response = append(response, timeSlotResponse)
+	}
+
+	request.RenderPaginatedResponse(c, response, total)
+}
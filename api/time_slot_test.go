@@ -5,10 +5,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/PRPO-skupina-02/common/database"
 	"github.com/PRPO-skupina-02/common/xtesting"
 	"github.com/PRPO-skupina-02/spored/db"
+	"github.com/PRPO-skupina-02/spored/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -79,12 +81,89 @@ func TestTimeSlotsList(t *testing.T) {
 			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
 		},
 		{
-			name:      "ok-invalid-date-format",
+			name:      "ok-filter-range",
 			status:    http.StatusOK,
+			params:    "?from=2026-01-02T16:00:00Z&to=2026-01-03",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name:      "ok-filter-time-of-day",
+			status:    http.StatusOK,
+			params:    "?start_after=20:00&start_before=21:00",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name:      "ok-filter-time-of-day-wrap",
+			status:    http.StatusOK,
+			params:    "?start_after=21:00&start_before=13:00",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name:      "ok-filter-weekday",
+			status:    http.StatusOK,
+			params:    "?weekday=saturday,Sunday",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name:      "ok-expand",
+			status:    http.StatusOK,
+			params:    "?expand=movie,room,theater&limit=2",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name:      "invalid-expand",
+			status:    http.StatusBadRequest,
+			params:    "?expand=movie,seats",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name:      "invalid-date-format",
+			status:    http.StatusBadRequest,
 			params:    "?date=invalid",
 			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
 			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
 		},
+		{
+			name:      "invalid-date-range",
+			status:    http.StatusBadRequest,
+			params:    "?from=yesterday",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name:      "invalid-time-of-day",
+			status:    http.StatusBadRequest,
+			params:    "?start_after=25:00",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name:      "invalid-weekday",
+			status:    http.StatusBadRequest,
+			params:    "?weekday=monday,funday",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name:      "ok-filter-status",
+			status:    http.StatusOK,
+			params:    "?status=CANCELLED",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
+		{
+			name:      "invalid-status",
+			status:    http.StatusBadRequest,
+			params:    "?status=SCHEDULED,UNKNOWN",
+			theaterID: "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:    "925c2358-df46-11f0-a38e-abe580bde3d1",
+		},
 		{
 			name:      "invalid-theater-id",
 			status:    http.StatusNotFound,
@@ -151,6 +230,7 @@ func TestTimeSlotsShow(t *testing.T) {
 		theaterID  string
 		roomID     string
 		timeSlotID string
+		params     string
 	}{
 		{
 			name:       "ok",
@@ -159,6 +239,29 @@ func TestTimeSlotsShow(t *testing.T) {
 			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
 			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
 		},
+		{
+			name:       "ok-expand",
+			status:     http.StatusOK,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
+			params:     "?expand=movie,room,theater",
+		},
+		{
+			name:       "ok-priced",
+			status:     http.StatusOK,
+			theaterID:  "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			roomID:     "ec19b8aa-df42-11f0-9018-53ba2f5e5e7c",
+			timeSlotID: "e843b5ce-c7ff-45e6-af57-94c88c9b8763",
+		},
+		{
+			name:       "invalid-expand",
+			status:     http.StatusBadRequest,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
+			params:     "?expand=rooms",
+		},
 		{
 			name:       "invalid-theater-id",
 			status:     http.StatusNotFound,
@@ -229,7 +332,7 @@ func TestTimeSlotsShow(t *testing.T) {
 			err := fixtures.Load()
 			assert.NoError(t, err)
 
-			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s/timeslots/%s", testCase.theaterID, testCase.roomID, testCase.timeSlotID)
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s/timeslots/%s%s", testCase.theaterID, testCase.roomID, testCase.timeSlotID, testCase.params)
 
 			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
 			w := httptest.NewRecorder()
@@ -241,3 +344,555 @@ func TestTimeSlotsShow(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeSlotsLock(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name       string
+		status     int
+		theaterID  string
+		roomID     string
+		timeSlotID string
+	}{
+		{
+			name:       "ok",
+			status:     http.StatusOK,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
+		},
+		{
+			name:       "invalid-room-id",
+			status:     http.StatusNotFound,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "01234567-0123-0123-0123-0123456789ab",
+			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
+		},
+		{
+			name:       "timeslot-from-different-room",
+			status:     http.StatusNotFound,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
+		},
+		{
+			name:       "invalid-timeslot-id",
+			status:     http.StatusNotFound,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:       "nil-timeslot-id",
+			status:     http.StatusBadRequest,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			name:       "malformed-timeslot-id",
+			status:     http.StatusBadRequest,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s/timeslots/%s/lock", testCase.theaterID, testCase.roomID, testCase.timeSlotID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPost, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			ignoreResp := xtesting.ValuesCheckers{
+				"updated_at": xtesting.ValueTimeInPastDuration(time.Second),
+			}
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w, ignoreResp)
+			xtesting.AssertGoldenDatabaseTable(t, db.Where("id = ?", "9d71d7fd-d88e-41a1-86dc-21b7f2550295"), []models.TimeSlot{}, map[string]xtesting.ValueChecker{"[0].UpdatedAt": xtesting.ValueTime()})
+		})
+	}
+}
+
+func TestTimeSlotsUnlock(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name       string
+		status     int
+		theaterID  string
+		roomID     string
+		timeSlotID string
+	}{
+		{
+			name:       "ok",
+			status:     http.StatusOK,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "9d71d7fd-d88e-41a1-86dc-21b7f2550295",
+		},
+		{
+			name:       "invalid-timeslot-id",
+			status:     http.StatusNotFound,
+			theaterID:  "bae209f6-d059-11f0-b2a4-cbf992c2eb6d",
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "01234567-0123-0123-0123-0123456789ab",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			lockURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s/timeslots/%s/lock", testCase.theaterID, testCase.roomID, "9d71d7fd-d88e-41a1-86dc-21b7f2550295")
+			r.ServeHTTP(httptest.NewRecorder(), xtesting.NewTestingRequest(t, lockURL, http.MethodPost, nil))
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/rooms/%s/timeslots/%s/unlock", testCase.theaterID, testCase.roomID, testCase.timeSlotID)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPost, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			ignoreResp := xtesting.ValuesCheckers{
+				"updated_at": xtesting.ValueTimeInPastDuration(time.Second),
+			}
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w, ignoreResp)
+			xtesting.AssertGoldenDatabaseTable(t, db.Where("id = ?", "9d71d7fd-d88e-41a1-86dc-21b7f2550295"), []models.TimeSlot{}, map[string]xtesting.ValueChecker{"[0].UpdatedAt": xtesting.ValueTime()})
+		})
+	}
+}
+
+func TestTimeSlotsCancel(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name            string
+		status          int
+		roomID          string
+		timeSlotID      string
+		params          string
+		body            any
+		ignoreTimeSlots xtesting.ValuesCheckers
+	}{
+		{
+			name:       "ok",
+			status:     http.StatusOK,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "fb5b6883-4f47-49ad-bcb5-6faec96e6ffc",
+			body: TimeSlotCancelRequest{
+				Reason: "Projector maintenance",
+			},
+			ignoreTimeSlots: xtesting.ValuesCheckers{
+				"[33].UpdatedAt":   xtesting.ValueTime(),
+				"[33].CancelledAt": xtesting.ValueTime(),
+			},
+		},
+		{
+			name:       "ok-force-reserved",
+			status:     http.StatusOK,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			params:     "?force=true",
+			body: TimeSlotCancelRequest{
+				Reason: "Projector maintenance",
+			},
+			ignoreTimeSlots: xtesting.ValuesCheckers{
+				"[32].UpdatedAt":   xtesting.ValueTime(),
+				"[32].CancelledAt": xtesting.ValueTime(),
+			},
+		},
+		{
+			name:       "reserved-timeslot",
+			status:     http.StatusConflict,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			body: TimeSlotCancelRequest{
+				Reason: "Projector maintenance",
+			},
+		},
+		{
+			name:       "invalid-force",
+			status:     http.StatusBadRequest,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			params:     "?force=maybe",
+			body: TimeSlotCancelRequest{
+				Reason: "Projector maintenance",
+			},
+		},
+		{
+			name:       "already-started",
+			status:     http.StatusBadRequest,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "3a634f82-3f2d-4566-9819-8d8fe7cb6150",
+			body: TimeSlotCancelRequest{
+				Reason: "Projector maintenance",
+			},
+		},
+		{
+			name:       "validation-errors",
+			status:     http.StatusBadRequest,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			body: TimeSlotCancelRequest{
+				Reason: "A",
+			},
+		},
+		{
+			name:       "no-body",
+			status:     http.StatusBadRequest,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+		},
+		{
+			name:       "timeslot-from-different-room",
+			status:     http.StatusNotFound,
+			roomID:     "925c2358-df46-11f0-a38e-abe580bde3d1",
+			timeSlotID: "b829f1ad-5667-49e4-a357-a1b0e1a3d9d6",
+			body: TimeSlotCancelRequest{
+				Reason: "Projector maintenance",
+			},
+		},
+		{
+			name:       "invalid-timeslot-id",
+			status:     http.StatusNotFound,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "01234567-0123-0123-0123-0123456789ab",
+			body: TimeSlotCancelRequest{
+				Reason: "Projector maintenance",
+			},
+		},
+		{
+			name:       "nil-timeslot-id",
+			status:     http.StatusBadRequest,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			name:       "malformed-timeslot-id",
+			status:     http.StatusBadRequest,
+			roomID:     "e0722c3a-df42-11f0-9579-3734395be62a",
+			timeSlotID: "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/bae209f6-d059-11f0-b2a4-cbf992c2eb6d/rooms/%s/timeslots/%s/cancel%s", testCase.roomID, testCase.timeSlotID, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPost, testCase.body)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			ignoreResp := xtesting.ValuesCheckers{
+				"updated_at":   xtesting.ValueTimeInPastDuration(time.Second),
+				"cancelled_at": xtesting.ValueTimeInPastDuration(time.Second),
+			}
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w, ignoreResp)
+			xtesting.AssertGoldenDatabaseTable(t, db.Where("room_id = ?", "e0722c3a-df42-11f0-9579-3734395be62a").Order("start_time"), []models.TimeSlot{}, testCase.ignoreTimeSlots)
+		})
+	}
+}
+
+func TestTheaterTimeSlotsList(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name      string
+		status    int
+		theaterID string
+		params    string
+	}{
+		{
+			name:      "ok",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+		},
+		{
+			name:      "ok-paginated",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?limit=5&offset=10",
+		},
+		{
+			name:      "ok-sort",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?sort=-start_time&limit=3",
+		},
+		{
+			name:      "ok-filter-date",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?date=2025-12-31",
+		},
+		{
+			name:      "ok-filter-date-range",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?from=2026-01-04&to=2026-01-05",
+		},
+		{
+			name:      "ok-filter-movie",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?movie_id=27e36818-e240-11f0-bb29-538173c01e43",
+		},
+		{
+			name:      "ok-expand",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?expand=room,theater&limit=2",
+		},
+		{
+			name:      "ok-filter-sold-out",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?sold_out=true",
+		},
+		{
+			name:      "ok-filter-not-sold-out",
+			status:    http.StatusOK,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?sold_out=false&date=2025-12-31",
+		},
+		{
+			name:      "invalid-sold-out",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?sold_out=maybe",
+		},
+		{
+			name:      "invalid-expand",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?expand=movie",
+		},
+		{
+			name:      "ok-no-rooms",
+			status:    http.StatusOK,
+			theaterID: "ea0b7f96-ddc9-11f0-9635-23efd36396bd",
+		},
+		{
+			name:      "invalid-date-range",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?from=2026/01/04",
+		},
+		{
+			name:      "invalid-movie-id",
+			status:    http.StatusBadRequest,
+			theaterID: "fb126c8c-d059-11f0-8fa4-b35f33be83b7",
+			params:    "?movie_id=000",
+		},
+		{
+			name:      "invalid-theater-id",
+			status:    http.StatusNotFound,
+			theaterID: "01234567-0123-0123-0123-0123456789ab",
+		},
+		{
+			name:      "nil-theater-id",
+			status:    http.StatusBadRequest,
+			theaterID: "00000000-0000-0000-0000-000000000000",
+		},
+		{
+			name:      "malformed-theater-id",
+			status:    http.StatusBadRequest,
+			theaterID: "000",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/%s/timeslots%s", testCase.theaterID, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodGet, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w)
+		})
+	}
+}
+
+func TestTimeSlotsShift(t *testing.T) {
+	db, fixtures := database.PrepareTestDatabase(t, db.FixtureFS, db.MigrationsFS)
+	r := TestingRouter(t, db)
+
+	tests := []struct {
+		name            string
+		status          int
+		roomID          string
+		params          string
+		body            any
+		ignoreResp      xtesting.ValuesCheckers
+		ignoreTimeSlots xtesting.ValuesCheckers
+	}{
+		{
+			name:   "ok",
+			status: http.StatusOK,
+			roomID: "e0722c3a-df42-11f0-9579-3734395be62a",
+			body: TimeSlotsShiftRequest{
+				After:   "2099-06-06T12:00:00Z",
+				Minutes: 30,
+			},
+		},
+		{
+			name:   "ok-flag-overflow",
+			status: http.StatusOK,
+			roomID: "e0722c3a-df42-11f0-9579-3734395be62a",
+			body: TimeSlotsShiftRequest{
+				After:   "2099-06-06T12:00:00Z",
+				Minutes: 120,
+			},
+		},
+		{
+			name:   "ok-drop-overflow",
+			status: http.StatusOK,
+			roomID: "e0722c3a-df42-11f0-9579-3734395be62a",
+			body: TimeSlotsShiftRequest{
+				After:    "2099-06-06T12:00:00Z",
+				Minutes:  120,
+				Overflow: "DROP",
+			},
+			ignoreResp: xtesting.ValuesCheckers{
+				"dropped.[0].cancelled_at": xtesting.ValueTime(),
+			},
+			ignoreTimeSlots: xtesting.ValuesCheckers{
+				"[35].CancelledAt": xtesting.ValueTime(),
+			},
+		},
+		{
+			name:   "ok-drop-overflow-force",
+			status: http.StatusOK,
+			roomID: "e0722c3a-df42-11f0-9579-3734395be62a",
+			params: "?force=true",
+			body: TimeSlotsShiftRequest{
+				After:    "2099-06-06T09:00:00Z",
+				Minutes:  600,
+				Overflow: "DROP",
+			},
+			ignoreResp: xtesting.ValuesCheckers{
+				"dropped.[0].cancelled_at": xtesting.ValueTime(),
+				"dropped.[1].cancelled_at": xtesting.ValueTime(),
+				"dropped.[2].cancelled_at": xtesting.ValueTime(),
+				"dropped.[3].cancelled_at": xtesting.ValueTime(),
+			},
+			ignoreTimeSlots: xtesting.GenerateValueCheckersForArraysWithOffset(map[string]xtesting.ValueChecker{"CancelledAt": xtesting.ValueTime()}, 4, 32),
+		},
+		{
+			name:   "reserved-drop-overflow",
+			status: http.StatusConflict,
+			roomID: "e0722c3a-df42-11f0-9579-3734395be62a",
+			body: TimeSlotsShiftRequest{
+				After:    "2099-06-06T09:00:00Z",
+				Minutes:  600,
+				Overflow: "DROP",
+			},
+		},
+		{
+			name:   "invalid-force",
+			status: http.StatusBadRequest,
+			roomID: "e0722c3a-df42-11f0-9579-3734395be62a",
+			params: "?force=maybe",
+			body: TimeSlotsShiftRequest{
+				After:    "2099-06-06T09:00:00Z",
+				Minutes:  600,
+				Overflow: "DROP",
+			},
+		},
+		{
+			name:   "ok-nothing-to-shift",
+			status: http.StatusOK,
+			roomID: "e0722c3a-df42-11f0-9579-3734395be62a",
+			body: TimeSlotsShiftRequest{
+				After:   "2099-06-06T21:00:00Z",
+				Minutes: 30,
+			},
+		},
+		{
+			name:   "validation-errors",
+			status: http.StatusBadRequest,
+			roomID: "e0722c3a-df42-11f0-9579-3734395be62a",
+			body: TimeSlotsShiftRequest{
+				After:    "soon",
+				Minutes:  1000,
+				Overflow: "KEEP",
+			},
+		},
+		{
+			name:   "no-body",
+			status: http.StatusBadRequest,
+			roomID: "e0722c3a-df42-11f0-9579-3734395be62a",
+		},
+		{
+			name:   "invalid-room-id",
+			status: http.StatusNotFound,
+			roomID: "01234567-0123-0123-0123-0123456789ab",
+			body: TimeSlotsShiftRequest{
+				After:   "2099-06-06T12:00:00Z",
+				Minutes: 30,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := fixtures.Load()
+			assert.NoError(t, err)
+
+			targetURL := fmt.Sprintf("/api/v1/spored/theaters/bae209f6-d059-11f0-b2a4-cbf992c2eb6d/rooms/%s/timeslots/shift%s", testCase.roomID, testCase.params)
+
+			req := xtesting.NewTestingRequest(t, targetURL, http.MethodPost, testCase.body)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			ignoreResp := xtesting.ValuesCheckers{}
+			for _, shifted := range []string{"moved", "dropped", "flagged"} {
+				for path, checker := range xtesting.GenerateValueCheckersForArrays(map[string]xtesting.ValueChecker{"updated_at": xtesting.ValueTime()}, 4) {
+					ignoreResp[shifted+"."+path] = checker
+				}
+			}
+			for path, checker := range testCase.ignoreResp {
+				ignoreResp[path] = checker
+			}
+
+			ignoreTimeSlots := xtesting.GenerateValueCheckersForArraysWithOffset(map[string]xtesting.ValueChecker{"UpdatedAt": xtesting.ValueTime()}, 4, 32)
+			for path, checker := range testCase.ignoreTimeSlots {
+				ignoreTimeSlots[path] = checker
+			}
+
+			assert.Equal(t, testCase.status, w.Code)
+			xtesting.AssertGoldenJSON(t, w, ignoreResp)
+			xtesting.AssertGoldenDatabaseTable(t, db.Where("room_id = ?", "e0722c3a-df42-11f0-9579-3734395be62a").Order("start_time"), []models.TimeSlot{}, ignoreTimeSlots)
+		})
+	}
+}
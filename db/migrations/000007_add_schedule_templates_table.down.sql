@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS schedule_templates;
+ALTER TABLE IF EXISTS rooms DROP COLUMN IF EXISTS schedule_mode;
+DROP TYPE IF EXISTS room_schedule_mode;
@@ -0,0 +1,16 @@
+CREATE TYPE room_schedule_mode AS ENUM ('AUTOMATIC', 'TEMPLATE');
+ALTER TABLE IF EXISTS rooms
+    ADD COLUMN schedule_mode room_schedule_mode NOT NULL DEFAULT 'AUTOMATIC';
+
+CREATE TABLE IF NOT EXISTS schedule_templates(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    room_id uuid NOT NULL,
+    movie_id uuid,
+    weekday int NOT NULL,
+    start_hour int NOT NULL,
+    start_minute int NOT NULL,
+    CONSTRAINT "ROOM_ID_FKEY" FOREIGN KEY (room_id) REFERENCES rooms(id),
+    CONSTRAINT "MOVIE_ID_FKEY" FOREIGN KEY (movie_id) REFERENCES movies(id)
+);
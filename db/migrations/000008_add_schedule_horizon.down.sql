@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS rooms DROP COLUMN IF EXISTS scheduled_until;
+ALTER TABLE IF EXISTS theaters DROP COLUMN IF EXISTS schedule_horizon_days;
@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS theaters
+    ADD COLUMN schedule_horizon_days int;
+
+ALTER TABLE IF EXISTS rooms
+    ADD COLUMN scheduled_until timestamptz;
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS movies DROP COLUMN IF EXISTS release_end_date;
+ALTER TABLE IF EXISTS movies DROP COLUMN IF EXISTS release_date;
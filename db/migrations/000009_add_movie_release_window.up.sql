@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS movies
+    ADD COLUMN release_date date;
+
+ALTER TABLE IF EXISTS movies
+    ADD COLUMN release_end_date date;
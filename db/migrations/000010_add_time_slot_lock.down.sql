@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS time_slots DROP COLUMN IF EXISTS locked;
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS time_slots
+    ADD COLUMN locked boolean NOT NULL DEFAULT false;
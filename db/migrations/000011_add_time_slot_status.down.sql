@@ -0,0 +1,10 @@
+ALTER TABLE IF EXISTS theaters DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE IF EXISTS rooms DROP COLUMN IF EXISTS deleted_at;
+
+DROP INDEX IF EXISTS time_slots_status_idx;
+
+ALTER TABLE IF EXISTS time_slots DROP COLUMN IF EXISTS cancelled_at;
+ALTER TABLE IF EXISTS time_slots DROP COLUMN IF EXISTS cancellation_reason;
+ALTER TABLE IF EXISTS time_slots DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS time_slot_status;
@@ -0,0 +1,18 @@
+CREATE TYPE time_slot_status AS ENUM ('SCHEDULED', 'CANCELLED', 'COMPLETED');
+
+ALTER TABLE IF EXISTS time_slots
+    ADD COLUMN status time_slot_status NOT NULL DEFAULT 'SCHEDULED';
+
+ALTER TABLE IF EXISTS time_slots
+    ADD COLUMN cancellation_reason varchar;
+
+ALTER TABLE IF EXISTS time_slots
+    ADD COLUMN cancelled_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS time_slots_status_idx ON time_slots(status);
+
+ALTER TABLE IF EXISTS rooms
+    ADD COLUMN deleted_at timestamptz;
+
+ALTER TABLE IF EXISTS theaters
+    ADD COLUMN deleted_at timestamptz;
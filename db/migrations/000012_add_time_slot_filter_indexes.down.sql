@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS time_slots_start_weekday_idx;
+DROP INDEX IF EXISTS time_slots_start_time_of_day_idx;
+DROP INDEX IF EXISTS time_slots_movie_id_start_time_idx;
+DROP INDEX IF EXISTS time_slots_room_id_start_time_idx;
@@ -0,0 +1,7 @@
+CREATE INDEX IF NOT EXISTS time_slots_room_id_start_time_idx ON time_slots(room_id, start_time);
+
+CREATE INDEX IF NOT EXISTS time_slots_movie_id_start_time_idx ON time_slots(movie_id, start_time);
+
+CREATE INDEX IF NOT EXISTS time_slots_start_time_of_day_idx ON time_slots(((start_time AT TIME ZONE 'UTC')::time));
+
+CREATE INDEX IF NOT EXISTS time_slots_start_weekday_idx ON time_slots((EXTRACT(ISODOW FROM start_time AT TIME ZONE 'UTC')));
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS time_slots DROP CONSTRAINT IF EXISTS time_slots_room_id_period_excl;
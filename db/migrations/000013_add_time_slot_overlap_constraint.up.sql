@@ -0,0 +1,34 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Repair overlaps that existed before the constraint by cancelling every time
+-- slot that starts before the previously kept one in the same room has ended
+DO $$
+DECLARE
+    slot record;
+    kept_room_id uuid;
+    kept_end_time timestamptz;
+BEGIN
+    FOR slot IN
+        SELECT id, room_id, start_time, end_time
+        FROM time_slots
+        WHERE status <> 'CANCELLED'
+        ORDER BY room_id, start_time, locked DESC, id
+    LOOP
+        IF slot.room_id = kept_room_id AND slot.start_time < kept_end_time THEN
+            UPDATE time_slots
+            SET status = 'CANCELLED',
+                cancellation_reason = 'Overlapped another time slot',
+                cancelled_at = now(),
+                updated_at = now()
+            WHERE id = slot.id;
+        ELSE
+            kept_room_id := slot.room_id;
+            kept_end_time := slot.end_time;
+        END IF;
+    END LOOP;
+END $$;
+
+ALTER TABLE IF EXISTS time_slots
+    ADD CONSTRAINT time_slots_room_id_period_excl
+    EXCLUDE USING gist (room_id WITH =, tstzrange(start_time, end_time) WITH &&)
+    WHERE (status <> 'CANCELLED');
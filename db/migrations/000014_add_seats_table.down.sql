@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS rooms DROP COLUMN IF EXISTS capacity;
+
+DROP TABLE IF EXISTS seats;
+
+DROP TYPE IF EXISTS seat_type;
@@ -0,0 +1,29 @@
+CREATE TYPE seat_type AS ENUM ('STANDARD', 'VIP', 'WHEELCHAIR', 'AISLE');
+
+CREATE TABLE IF NOT EXISTS seats(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    room_id uuid NOT NULL,
+    "row" int NOT NULL,
+    "column" int NOT NULL,
+    row_label varchar NOT NULL,
+    type seat_type NOT NULL DEFAULT 'STANDARD',
+    disabled boolean NOT NULL DEFAULT false,
+    CONSTRAINT "ROOM_ID_FKEY" FOREIGN KEY (room_id) REFERENCES rooms(id),
+    CONSTRAINT seats_room_id_position_key UNIQUE (room_id, "row", "column")
+);
+
+ALTER TABLE IF EXISTS rooms
+    ADD COLUMN capacity int NOT NULL DEFAULT 0;
+
+-- Seat the existing rooms with standard seats, labelling rows A to Z, AA, ...
+INSERT INTO seats (room_id, "row", "column", row_label)
+SELECT rooms.id, r, c,
+    CASE WHEN r <= 26 THEN chr(64 + r)
+    ELSE chr(64 + (r - 1) / 26) || chr(65 + (r - 1) % 26) END
+FROM rooms
+CROSS JOIN LATERAL generate_series(1, rooms.rows) AS r
+CROSS JOIN LATERAL generate_series(1, rooms.columns) AS c;
+
+UPDATE rooms SET capacity = rooms.rows * rooms.columns;
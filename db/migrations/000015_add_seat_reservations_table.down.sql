@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS seat_reservations;
+
+DROP TYPE IF EXISTS seat_reservation_status;
@@ -0,0 +1,18 @@
+CREATE TYPE seat_reservation_status AS ENUM ('HELD', 'CONFIRMED');
+
+CREATE TABLE IF NOT EXISTS seat_reservations(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    hold_id uuid NOT NULL,
+    status seat_reservation_status NOT NULL DEFAULT 'HELD',
+    expires_at timestamptz,
+    time_slot_id uuid NOT NULL,
+    seat_id uuid NOT NULL,
+    CONSTRAINT "TIME_SLOT_ID_FKEY" FOREIGN KEY (time_slot_id) REFERENCES time_slots(id) ON DELETE CASCADE,
+    CONSTRAINT "SEAT_ID_FKEY" FOREIGN KEY (seat_id) REFERENCES seats(id) ON DELETE CASCADE,
+    CONSTRAINT seat_reservations_time_slot_id_seat_id_key UNIQUE (time_slot_id, seat_id)
+);
+
+CREATE INDEX IF NOT EXISTS seat_reservations_hold_id_idx ON seat_reservations(hold_id);
+CREATE INDEX IF NOT EXISTS seat_reservations_expires_at_idx ON seat_reservations(expires_at) WHERE status = 'HELD';
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS time_slot_prices;
+
+DROP TABLE IF EXISTS time_of_day_surcharges;
+
+DROP TABLE IF EXISTS price_lists;
+
+ALTER TABLE IF EXISTS rooms DROP COLUMN IF EXISTS imax;
+ALTER TABLE IF EXISTS rooms DROP COLUMN IF EXISTS three_d;
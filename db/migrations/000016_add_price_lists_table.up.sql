@@ -0,0 +1,43 @@
+ALTER TABLE IF EXISTS rooms
+    ADD COLUMN IF NOT EXISTS three_d boolean NOT NULL DEFAULT false;
+ALTER TABLE IF EXISTS rooms
+    ADD COLUMN IF NOT EXISTS imax boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS price_lists(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    currency varchar(3) NOT NULL DEFAULT 'EUR',
+    standard_price integer NOT NULL,
+    vip_price integer NOT NULL,
+    wheelchair_price integer NOT NULL,
+    three_d_surcharge integer NOT NULL DEFAULT 0,
+    imax_surcharge integer NOT NULL DEFAULT 0,
+    weekday_surcharge integer NOT NULL DEFAULT 0,
+    weekend_surcharge integer NOT NULL DEFAULT 0,
+    theater_id uuid NOT NULL,
+    CONSTRAINT "THEATER_ID_FKEY" FOREIGN KEY (theater_id) REFERENCES theaters(id) ON DELETE CASCADE,
+    CONSTRAINT price_lists_theater_id_key UNIQUE (theater_id)
+);
+
+CREATE TABLE IF NOT EXISTS time_of_day_surcharges(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    from_hour integer NOT NULL,
+    to_hour integer NOT NULL,
+    amount integer NOT NULL,
+    price_list_id uuid NOT NULL,
+    CONSTRAINT "PRICE_LIST_ID_FKEY" FOREIGN KEY (price_list_id) REFERENCES price_lists(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS time_slot_prices(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    seat_type seat_type NOT NULL,
+    price integer NOT NULL,
+    time_slot_id uuid NOT NULL,
+    CONSTRAINT "TIME_SLOT_ID_FKEY" FOREIGN KEY (time_slot_id) REFERENCES time_slots(id) ON DELETE CASCADE,
+    CONSTRAINT time_slot_prices_time_slot_id_seat_type_key UNIQUE (time_slot_id, seat_type)
+);
@@ -0,0 +1,18 @@
+CREATE TYPE discount_type AS ENUM ('PERCENT', 'AMOUNT', 'FIXED_PRICE');
+
+CREATE TABLE IF NOT EXISTS promotions(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    name varchar(255) NOT NULL,
+    weekdays jsonb NOT NULL DEFAULT '[]',
+    start_after varchar(5),
+    start_before varchar(5),
+    movie_ids jsonb NOT NULL DEFAULT '[]',
+    theater_ids jsonb NOT NULL DEFAULT '[]',
+    ticket_types jsonb NOT NULL DEFAULT '[]',
+    valid_from date,
+    valid_until date,
+    discount_type discount_type NOT NULL,
+    discount_value integer NOT NULL
+);
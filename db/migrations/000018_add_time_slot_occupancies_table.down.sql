@@ -0,0 +1 @@
+DROP TABLE IF EXISTS time_slot_occupancies;
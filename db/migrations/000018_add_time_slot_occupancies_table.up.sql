@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS time_slot_occupancies(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    sold integer NOT NULL DEFAULT 0,
+    held integer NOT NULL DEFAULT 0,
+    time_slot_id uuid NOT NULL,
+    CONSTRAINT "TIME_SLOT_ID_FKEY" FOREIGN KEY (time_slot_id) REFERENCES time_slots(id) ON DELETE CASCADE,
+    CONSTRAINT time_slot_occupancies_time_slot_id_key UNIQUE (time_slot_id)
+);
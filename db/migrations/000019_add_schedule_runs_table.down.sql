@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS schedule_run_rooms;
+DROP TABLE IF EXISTS movie_demands;
+DROP TABLE IF EXISTS schedule_runs;
+DROP TYPE IF EXISTS schedule_run_kind;
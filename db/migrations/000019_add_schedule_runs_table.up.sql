@@ -0,0 +1,35 @@
+CREATE TYPE schedule_run_kind AS ENUM ('POPULATE', 'EXTEND');
+
+CREATE TABLE IF NOT EXISTS schedule_runs(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    kind schedule_run_kind NOT NULL,
+    theater_id uuid NOT NULL,
+    CONSTRAINT "THEATER_ID_FKEY" FOREIGN KEY (theater_id) REFERENCES theaters(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS movie_demands(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    screenings integer NOT NULL,
+    occupancy double precision NOT NULL,
+    weight double precision NOT NULL,
+    schedule_run_id uuid NOT NULL,
+    movie_id uuid NOT NULL,
+    CONSTRAINT "SCHEDULE_RUN_ID_FKEY" FOREIGN KEY (schedule_run_id) REFERENCES schedule_runs(id) ON DELETE CASCADE,
+    CONSTRAINT "MOVIE_ID_FKEY" FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS schedule_run_rooms(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    capacity integer NOT NULL,
+    exponent double precision NOT NULL,
+    schedule_run_id uuid NOT NULL,
+    room_id uuid NOT NULL,
+    CONSTRAINT "SCHEDULE_RUN_ID_FKEY" FOREIGN KEY (schedule_run_id) REFERENCES schedule_runs(id) ON DELETE CASCADE,
+    CONSTRAINT "ROOM_ID_FKEY" FOREIGN KEY (room_id) REFERENCES rooms(id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS seat_reservations_user_id_idx;
+ALTER TABLE IF EXISTS seat_reservations DROP COLUMN IF EXISTS user_id;
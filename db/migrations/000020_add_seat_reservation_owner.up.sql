@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS seat_reservations
+    ADD COLUMN user_id uuid;
+
+CREATE INDEX IF NOT EXISTS seat_reservations_user_id_idx ON seat_reservations(user_id);
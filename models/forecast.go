@@ -0,0 +1,253 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// Time slots that started within this many days train the forecast model
+	forecastLookbackDays = 90
+	// Added to the number of time slots behind every effect, so that effects
+	// seen only a few times stay close to the average
+	forecastSmoothing = 3
+
+	// Time slots forecast to sell less than this share of seats are likely
+	// to stay empty
+	LikelyEmptyOccupancy = 0.15
+)
+
+// forecastFeatures are the features of a time slot the forecast model learns
+// an effect for, in the order the effects are fitted
+var forecastFeatures = []func(timeSlot TimeSlot, capacity int) string{
+	func(timeSlot TimeSlot, capacity int) string {
+		return timeSlot.MovieID.String()
+	},
+	func(timeSlot TimeSlot, capacity int) string {
+		return timeSlot.StartTime.UTC().Weekday().String()
+	},
+	func(timeSlot TimeSlot, capacity int) string {
+		return fmt.Sprint(timeSlot.StartTime.UTC().Hour())
+	},
+	roomSizeFeature,
+	releaseAgeFeature,
+}
+
+func roomSizeFeature(timeSlot TimeSlot, capacity int) string {
+	switch {
+	case capacity <= 50:
+		return "small"
+	case capacity <= 150:
+		return "medium"
+	case capacity <= 300:
+		return "large"
+	default:
+		return "huge"
+	}
+}
+
+// releaseAgeFeature buckets the weeks since the release of the movie, movies
+// without a release date count from when they were added
+func releaseAgeFeature(timeSlot TimeSlot, capacity int) string {
+	released := timeSlot.Movie.CreatedAt
+	if timeSlot.Movie.ReleaseDate != nil {
+		released = *timeSlot.Movie.ReleaseDate
+	}
+
+	weeks := int(timeSlot.StartTime.Truncate(durationDay).Sub(released.Truncate(durationDay)) / (durationDay * 7))
+	return fmt.Sprint(min(max(weeks, 0), 3))
+}
+
+// OccupancyModel predicts the share of seats sold for a time slot as the
+// average occupancy plus an effect for each of its features. Every effect is
+// fitted on what the previous ones left unexplained.
+type OccupancyModel struct {
+	Samples int
+
+	mean    float64
+	effects []map[string]float64
+}
+
+// TrainOccupancyModel fits the model on the time slots of every theater that
+// ended within the lookback period before now
+func TrainOccupancyModel(tx *gorm.DB, now time.Time) (OccupancyModel, error) {
+	var timeSlots []TimeSlot
+	query := tx.Where("time_slots.status <> ?", CancelledTimeSlot).
+		Where("time_slots.start_time >= ? AND time_slots.end_time <= ?", now.AddDate(0, 0, -forecastLookbackDays), now).
+		Preload("Movie", unscopedScope)
+	if err := query.Find(&timeSlots).Error; err != nil {
+		return OccupancyModel{}, err
+	}
+
+	occupancies, err := ResolveTimeSlotOccupancies(tx, timeSlots, now)
+	if err != nil {
+		return OccupancyModel{}, err
+	}
+
+	type sample struct {
+		features []string
+		residual float64
+	}
+	samples := []sample{}
+	total := 0.0
+	for _, timeSlot := range timeSlots {
+		occupancy := occupancies[timeSlot.ID]
+		if occupancy.Capacity == 0 {
+			continue
+		}
+
+		features := []string{}
+		for _, feature := range forecastFeatures {
+			features = append(features, feature(timeSlot, occupancy.Capacity))
+		}
+
+		sold := math.Min(1, float64(occupancy.Sold)/float64(occupancy.Capacity))
+		samples = append(samples, sample{features: features, residual: sold})
+		total += sold
+	}
+
+	model := OccupancyModel{
+		Samples: len(samples),
+	}
+	if len(samples) == 0 {
+		return model, nil
+	}
+
+	model.mean = total / float64(len(samples))
+	for i := range samples {
+		samples[i].residual -= model.mean
+	}
+
+	for i := range forecastFeatures {
+		sums := map[string]float64{}
+		counts := map[string]int{}
+		for _, sample := range samples {
+			sums[sample.features[i]] += sample.residual
+			counts[sample.features[i]]++
+		}
+
+		effects := map[string]float64{}
+		for value, sum := range sums {
+			effects[value] = sum / float64(counts[value]+forecastSmoothing)
+		}
+		model.effects = append(model.effects, effects)
+
+		for j := range samples {
+			samples[j].residual -= effects[samples[j].features[i]]
+		}
+	}
+
+	return model, nil
+}
+
+// OccupancyForecast is the predicted share and number of seats sold for a
+// time slot
+type OccupancyForecast struct {
+	TimeSlot    TimeSlot
+	Capacity    int
+	Occupancy   float64
+	Seats       int
+	LikelyEmpty bool
+	Samples     int
+}
+
+// Forecast predicts the occupancy of the time slot, which needs its movie
+// preloaded. Without any samples nothing is flagged as likely empty.
+func (m *OccupancyModel) Forecast(timeSlot TimeSlot, capacity int) OccupancyForecast {
+	occupancy := m.mean
+	for i, feature := range forecastFeatures {
+		if i < len(m.effects) {
+			occupancy += m.effects[i][feature(timeSlot, capacity)]
+		}
+	}
+	occupancy = roundToPrecision(math.Max(0, math.Min(1, occupancy)), 3)
+
+	return OccupancyForecast{
+		TimeSlot:    timeSlot,
+		Capacity:    capacity,
+		Occupancy:   occupancy,
+		Seats:       int(math.Round(occupancy * float64(capacity))),
+		LikelyEmpty: m.Samples > 0 && occupancy < LikelyEmptyOccupancy,
+		Samples:     m.Samples,
+	}
+}
+
+// occupancyModelCache keeps the model of the day forecasts were last made
+// for, so that it is trained once a day instead of on every forecast
+var occupancyModelCache struct {
+	sync.Mutex
+	day       time.Time
+	trainedAt time.Time
+	model     *OccupancyModel
+}
+
+// GetOccupancyModel returns the model cached for the day of now, or trains it
+// as of now. A model trained later than now is not reused, so that forecasts
+// never learn from time slots that ended after now.
+func GetOccupancyModel(tx *gorm.DB, now time.Time) (OccupancyModel, error) {
+	day := now.Truncate(durationDay)
+
+	occupancyModelCache.Lock()
+	defer occupancyModelCache.Unlock()
+
+	cached := occupancyModelCache.model
+	if cached != nil && occupancyModelCache.day.Equal(day) && !now.Before(occupancyModelCache.trainedAt) {
+		return *cached, nil
+	}
+
+	model, err := TrainOccupancyModel(tx, now)
+	if err != nil {
+		return OccupancyModel{}, err
+	}
+
+	occupancyModelCache.day = day
+	occupancyModelCache.trainedAt = now
+	occupancyModelCache.model = &model
+
+	return model, nil
+}
+
+// ForecastTimeSlots forecasts every time slot with the model of the day of now
+func ForecastTimeSlots(tx *gorm.DB, timeSlots []TimeSlot, now time.Time) ([]OccupancyForecast, error) {
+	model, err := GetOccupancyModel(tx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	occupancies, err := ResolveTimeSlotOccupancies(tx, timeSlots, now)
+	if err != nil {
+		return nil, err
+	}
+
+	forecasts := []OccupancyForecast{}
+	for _, timeSlot := range timeSlots {
+		forecasts = append(forecasts, model.Forecast(timeSlot, occupancies[timeSlot.ID].Capacity))
+	}
+
+	return forecasts, nil
+}
+
+// GetTheaterDayForecastTimeSlots returns the scheduled time slots of the
+// theater that start on the day but after now, ordered by start
+func GetTheaterDayForecastTimeSlots(tx *gorm.DB, theaterID uuid.UUID, day, now time.Time) ([]TimeSlot, error) {
+	from := day.Truncate(durationDay)
+	to := from.Add(durationDay)
+
+	var timeSlots []TimeSlot
+	query := tx.Joins("JOIN rooms ON rooms.id = time_slots.room_id").
+		Where("rooms.theater_id = ? AND rooms.deleted_at IS NULL", theaterID).
+		Where("time_slots.status = ?", ScheduledTimeSlot).
+		Where("time_slots.start_time >= ? AND time_slots.start_time < ? AND time_slots.start_time > ?", from, to, now).
+		Preload("Movie", unscopedScope).
+		Order("time_slots.start_time, rooms.name, time_slots.id")
+	if err := query.Find(&timeSlots).Error; err != nil {
+		return nil, err
+	}
+
+	return timeSlots, nil
+}
@@ -24,7 +24,12 @@ type Movie struct {
 	LengthMinutes int
 	Active        bool
 
-	TimeSlots []TimeSlot `gorm:"foreignKey:MovieID" json:"-"`
+	// Release window bounds are inclusive, nil means unbounded
+	ReleaseDate    *time.Time
+	ReleaseEndDate *time.Time
+
+	TimeSlots         []TimeSlot         `gorm:"foreignKey:MovieID" json:"-"`
+	ScheduleTemplates []ScheduleTemplate `gorm:"foreignKey:MovieID" json:"-"`
 }
 
 func roundToPrecision(val float64, precision uint) float64 {
@@ -88,7 +93,7 @@ func DeleteMovie(tx *gorm.DB, id uuid.UUID) error {
 		ID: id,
 	}
 
-	if err := tx.Where(&movie).Preload("TimeSlots").First(&movie).Error; err != nil {
+	if err := tx.Where(&movie).Preload("TimeSlots").Preload("ScheduleTemplates").First(&movie).Error; err != nil {
 		return err
 	}
 
@@ -99,6 +104,13 @@ func DeleteMovie(tx *gorm.DB, id uuid.UUID) error {
 		}
 	}
 
+	if len(movie.ScheduleTemplates) != 0 {
+		return &middleware.HttpError{
+			Code:    http.StatusBadRequest,
+			Message: "Movie is still present in schedule templates",
+		}
+	}
+
 	if err := tx.Delete(&movie).Error; err != nil {
 		return err
 	}
@@ -113,8 +125,36 @@ func (m *Movie) CalculateEndTime(startTime time.Time) time.Time {
 	return startTime.Add(time.Duration(roundedTo10Mins) * time.Minute)
 }
 
-func WeighedSelectMovie(movies []Movie) Movie {
-	// TODO: weigh random selection
-	selection := rand.IntN(len(movies))
-	return movies[selection]
+func (m *Movie) InReleaseWindow(instant time.Time) bool {
+	day := instant.Truncate(durationDay)
+	if m.ReleaseDate != nil && day.Before(m.ReleaseDate.Truncate(durationDay)) {
+		return false
+	}
+	if m.ReleaseEndDate != nil && day.After(m.ReleaseEndDate.Truncate(durationDay)) {
+		return false
+	}
+	return true
+}
+
+// IsScreenable reports whether the movie can be scheduled at the given instant
+func (m *Movie) IsScreenable(instant time.Time) bool {
+	return m.Active && m.InReleaseWindow(instant)
+}
+
+// WeighedSelectMovie picks a random movie with a chance proportional to its
+// weight
+func WeighedSelectMovie(movies []Movie, weights MovieWeights) Movie {
+	total := 0.0
+	for _, movie := range movies {
+		total += weights.Of(movie.ID)
+	}
+
+	selection := rand.Float64() * total
+	for _, movie := range movies {
+		selection -= weights.Of(movie.ID)
+		if selection < 0 {
+			return movie
+		}
+	}
+	return movies[len(movies)-1]
 }
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RoomNowPlaying holds the time slot running in a room at an instant and the
+// ones following it
+type RoomNowPlaying struct {
+	Room    Room
+	Current *TimeSlot
+	Next    []TimeSlot
+}
+
+// GetTheaterNowPlaying returns what runs at the instant and the next time slots
+// of every room in the theater, rooms are ordered by name
+func GetTheaterNowPlaying(tx *gorm.DB, theaterID uuid.UUID, instant time.Time, next int) ([]RoomNowPlaying, error) {
+	var rooms []Room
+	if err := tx.Where("rooms.theater_id = ?", theaterID).Order("rooms.name, rooms.id").Find(&rooms).Error; err != nil {
+		return nil, err
+	}
+
+	result := []RoomNowPlaying{}
+
+	for _, room := range rooms {
+		var timeSlots []TimeSlot
+
+		query := tx.Where("time_slots.room_id = ?", room.ID).
+			Where("time_slots.status <> ?", CancelledTimeSlot).
+			Where("time_slots.end_time > ?", instant)
+
+		if err := query.Order("time_slots.start_time").Limit(next + 1).Preload("Movie").Find(&timeSlots).Error; err != nil {
+			return nil, err
+		}
+
+		nowPlaying := RoomNowPlaying{
+			Room: room,
+			Next: []TimeSlot{},
+		}
+
+		for _, timeSlot := range timeSlots {
+			if nowPlaying.Current == nil && timeSlot.CoversInstant(instant) {
+				nowPlaying.Current = &timeSlot
+				continue
+			}
+			if len(nowPlaying.Next) < next {
+				nowPlaying.Next = append(nowPlaying.Next, timeSlot)
+			}
+		}
+
+		result = append(result, nowPlaying)
+	}
+
+	return result, nil
+}
+
+// Progress returns the elapsed share of the time slot at the instant in whole
+// percent, clamped to [0, 100]
+func (ts *TimeSlot) Progress(instant time.Time) int {
+	length := ts.EndTime.Sub(ts.StartTime)
+	if length <= 0 {
+		return 100
+	}
+
+	progress := int(instant.Sub(ts.StartTime) * 100 / length)
+	return max(0, min(progress, 100))
+}
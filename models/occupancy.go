@@ -0,0 +1,157 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TimeSlotOccupancy is the number of seats of a time slot that the external
+// booking system reports as sold and held, on top of the seats reserved
+// through seat holds
+type TimeSlotOccupancy struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Sold int
+	Held int
+
+	TimeSlotID uuid.UUID
+	TimeSlot   TimeSlot `gorm:"foreignKey:TimeSlotID" json:"-"`
+}
+
+func (o *TimeSlotOccupancy) Save(tx *gorm.DB) error {
+	if err := tx.Save(o).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetOrNewTimeSlotOccupancy returns the reported occupancy of the time slot, or
+// an empty one that has not been saved yet
+func GetOrNewTimeSlotOccupancy(tx *gorm.DB, timeSlotID uuid.UUID) (TimeSlotOccupancy, error) {
+	occupancy := TimeSlotOccupancy{}
+
+	err := tx.Where("time_slot_occupancies.time_slot_id = ?", timeSlotID).First(&occupancy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return TimeSlotOccupancy{
+			ID:         uuid.New(),
+			TimeSlotID: timeSlotID,
+		}, nil
+	}
+	if err != nil {
+		return occupancy, err
+	}
+
+	return occupancy, nil
+}
+
+// Occupancy of a time slot, sold and held seats combine the reported
+// occupancy with the active seat reservations
+type Occupancy struct {
+	Capacity int
+	Sold     int
+	Held     int
+}
+
+func (o Occupancy) Available() int {
+	return max(0, o.Capacity-o.Sold-o.Held)
+}
+
+func (o Occupancy) SoldOut() bool {
+	return o.Available() == 0
+}
+
+// ResolveTimeSlotOccupancies returns the occupancy of every time slot, with the
+// capacity of its room
+func ResolveTimeSlotOccupancies(tx *gorm.DB, timeSlots []TimeSlot, now time.Time) (map[uuid.UUID]Occupancy, error) {
+	occupancies := map[uuid.UUID]Occupancy{}
+	if len(timeSlots) == 0 {
+		return occupancies, nil
+	}
+
+	timeSlotIDs := []uuid.UUID{}
+	roomIDs := []uuid.UUID{}
+	for _, timeSlot := range timeSlots {
+		timeSlotIDs = append(timeSlotIDs, timeSlot.ID)
+		roomIDs = append(roomIDs, timeSlot.RoomID)
+	}
+
+	var rooms []Room
+	if err := tx.Unscoped().Where("rooms.id IN ?", roomIDs).Find(&rooms).Error; err != nil {
+		return nil, err
+	}
+
+	capacities := map[uuid.UUID]int{}
+	for _, room := range rooms {
+		capacities[room.ID] = room.Capacity
+	}
+
+	var reported []TimeSlotOccupancy
+	if err := tx.Where("time_slot_occupancies.time_slot_id IN ?", timeSlotIDs).Find(&reported).Error; err != nil {
+		return nil, err
+	}
+
+	var counts []struct {
+		TimeSlotID uuid.UUID
+		Status     SeatReservationStatus
+		Seats      int
+	}
+	query := tx.Model(&SeatReservation{}).
+		Select("seat_reservations.time_slot_id, seat_reservations.status, COUNT(*) AS seats").
+		Where("seat_reservations.time_slot_id IN ?", timeSlotIDs).
+		Scopes(activeSeatReservationsScope(now)).
+		Group("seat_reservations.time_slot_id, seat_reservations.status")
+	if err := query.Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, timeSlot := range timeSlots {
+		occupancies[timeSlot.ID] = Occupancy{
+			Capacity: capacities[timeSlot.RoomID],
+		}
+	}
+
+	for _, occupancy := range reported {
+		resolved := occupancies[occupancy.TimeSlotID]
+		resolved.Sold += occupancy.Sold
+		resolved.Held += occupancy.Held
+		occupancies[occupancy.TimeSlotID] = resolved
+	}
+
+	for _, count := range counts {
+		resolved := occupancies[count.TimeSlotID]
+		if count.Status == ConfirmedSeatReservation {
+			resolved.Sold += count.Seats
+		} else {
+			resolved.Held += count.Seats
+		}
+		occupancies[count.TimeSlotID] = resolved
+	}
+
+	return occupancies, nil
+}
+
+// occupiedSeatsExpression counts the reported and actively reserved seats of
+// a time slot
+const occupiedSeatsExpression = `(COALESCE((SELECT time_slot_occupancies.sold + time_slot_occupancies.held FROM time_slot_occupancies WHERE time_slot_occupancies.time_slot_id = time_slots.id), 0)
+	+ (SELECT COUNT(*) FROM seat_reservations WHERE seat_reservations.time_slot_id = time_slots.id AND (seat_reservations.status = ? OR seat_reservations.expires_at > ?)))`
+
+// TimeSlotSoldOutFilter limits time slots to the ones that are sold out, or to
+// the ones that still have available seats
+type TimeSlotSoldOutFilter struct {
+	SoldOut bool
+	Now     time.Time
+}
+
+func (f TimeSlotSoldOutFilter) Apply(db *gorm.DB) *gorm.DB {
+	condition := fmt.Sprintf("%s >= (SELECT rooms.capacity FROM rooms WHERE rooms.id = time_slots.room_id)", occupiedSeatsExpression)
+	if !f.SoldOut {
+		condition = fmt.Sprintf("NOT (%s)", condition)
+	}
+	return db.Where(condition, ConfirmedSeatReservation, f.Now)
+}
@@ -0,0 +1,23 @@
+package models
+
+import "gorm.io/gorm"
+
+// PreloadScope preloads a single association, nested associations are
+// separated with a dot
+func PreloadScope(association string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Preload(association)
+	}
+}
+
+// UnscopedPreloadScope preloads a single association including soft deleted
+// records
+func UnscopedPreloadScope(association string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Preload(association, unscopedScope)
+	}
+}
+
+func unscopedScope(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
@@ -0,0 +1,329 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const DefaultCurrency = "EUR"
+
+// PricedSeatTypes are the seat types that tickets are sold for, aisles are
+// never priced
+var PricedSeatTypes = []SeatType{StandardSeat, VIPSeat, WheelchairSeat}
+
+// PriceList holds the prices of a theater in cents. A ticket costs the base
+// price of its seat type plus every surcharge that applies to the time slot,
+// surcharges can be negative to give discounts.
+type PriceList struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Currency        string
+	StandardPrice   int
+	VIPPrice        int
+	WheelchairPrice int
+
+	ThreeDSurcharge  int
+	IMAXSurcharge    int
+	WeekdaySurcharge int
+	WeekendSurcharge int
+
+	TheaterID           uuid.UUID
+	Theater             Theater              `gorm:"foreignKey:TheaterID" json:"-"`
+	TimeOfDaySurcharges []TimeOfDaySurcharge `gorm:"foreignKey:PriceListID" json:"-"`
+}
+
+// TimeOfDaySurcharge applies to time slots starting from FromHour up to, but
+// not including, ToHour in UTC
+type TimeOfDaySurcharge struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	FromHour int
+	ToHour   int
+	Amount   int
+
+	PriceListID uuid.UUID
+	PriceList   PriceList `gorm:"foreignKey:PriceListID" json:"-"`
+}
+
+// TimeSlotPrice overrides the resolved price of a seat type for a single time
+// slot
+type TimeSlotPrice struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	SeatType SeatType
+	Price    int
+
+	TimeSlotID uuid.UUID
+	TimeSlot   TimeSlot `gorm:"foreignKey:TimeSlotID" json:"-"`
+}
+
+// SeatPrice is the price of a seat type for a time slot
+type SeatPrice struct {
+	SeatType   SeatType
+	Price      int
+	Overridden bool
+}
+
+// PriceTable holds the resolved prices of a time slot ordered by seat type
+type PriceTable struct {
+	Currency string
+	Prices   []SeatPrice
+}
+
+func preloadOrderedTimeOfDaySurchargesScope(db *gorm.DB) *gorm.DB {
+	return db.Preload("TimeOfDaySurcharges", func(db *gorm.DB) *gorm.DB {
+		return db.Order("time_of_day_surcharges.from_hour")
+	})
+}
+
+func GetTheaterPriceList(tx *gorm.DB, theaterID uuid.UUID) (PriceList, error) {
+	priceList := PriceList{
+		TheaterID: theaterID,
+	}
+
+	if err := tx.Where(&priceList).Scopes(preloadOrderedTimeOfDaySurchargesScope).First(&priceList).Error; err != nil {
+		return priceList, err
+	}
+
+	return priceList, nil
+}
+
+// GetOrNewTheaterPriceList returns the price list of the theater, or a new
+// one that is not stored yet when the theater has none
+func GetOrNewTheaterPriceList(tx *gorm.DB, theaterID uuid.UUID) (PriceList, error) {
+	priceList, err := GetTheaterPriceList(tx, theaterID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return PriceList{
+			ID:        uuid.New(),
+			TheaterID: theaterID,
+		}, nil
+	}
+	return priceList, err
+}
+
+// Save stores the price list and replaces its time of day surcharges
+func (pl *PriceList) Save(tx *gorm.DB) error {
+	if err := tx.Omit("TimeOfDaySurcharges").Save(pl).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("price_list_id = ?", pl.ID).Delete(&TimeOfDaySurcharge{}).Error; err != nil {
+		return err
+	}
+
+	for i := range pl.TimeOfDaySurcharges {
+		pl.TimeOfDaySurcharges[i].PriceListID = pl.ID
+	}
+
+	if len(pl.TimeOfDaySurcharges) > 0 {
+		if err := tx.Create(&pl.TimeOfDaySurcharges).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func DeleteTheaterPriceList(tx *gorm.DB, theaterID uuid.UUID) error {
+	priceList, err := GetTheaterPriceList(tx, theaterID)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Delete(&priceList).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (pl *PriceList) BasePrice(seatType SeatType) int {
+	switch seatType {
+	case VIPSeat:
+		return pl.VIPPrice
+	case WheelchairSeat:
+		return pl.WheelchairPrice
+	default:
+		return pl.StandardPrice
+	}
+}
+
+// Surcharge sums up the surcharges for the features of the room and the start
+// of the time slot
+func (pl *PriceList) Surcharge(room Room, start time.Time) int {
+	surcharge := 0
+
+	if room.ThreeD {
+		surcharge += pl.ThreeDSurcharge
+	}
+	if room.IMAX {
+		surcharge += pl.IMAXSurcharge
+	}
+
+	start = start.UTC()
+	switch start.Weekday() {
+	case time.Saturday, time.Sunday:
+		surcharge += pl.WeekendSurcharge
+	default:
+		surcharge += pl.WeekdaySurcharge
+	}
+
+	for _, timeOfDay := range pl.TimeOfDaySurcharges {
+		if start.Hour() >= timeOfDay.FromHour && start.Hour() < timeOfDay.ToHour {
+			surcharge += timeOfDay.Amount
+		}
+	}
+
+	return surcharge
+}
+
+// Resolve prices every seat type for a time slot in the room, prices never
+// drop below zero
+func (pl *PriceList) Resolve(room Room, timeSlot TimeSlot, overrides []TimeSlotPrice) PriceTable {
+	table := PriceTable{
+		Currency: pl.Currency,
+		Prices:   []SeatPrice{},
+	}
+	surcharge := pl.Surcharge(room, timeSlot.StartTime)
+
+	for _, seatType := range PricedSeatTypes {
+		table.Prices = append(table.Prices, SeatPrice{
+			SeatType: seatType,
+			Price:    max(0, pl.BasePrice(seatType)+surcharge),
+		})
+	}
+
+	return table.override(overrides)
+}
+
+func (pt PriceTable) override(overrides []TimeSlotPrice) PriceTable {
+	for _, override := range overrides {
+		found := false
+		for i := range pt.Prices {
+			if pt.Prices[i].SeatType == override.SeatType {
+				pt.Prices[i].Price = override.Price
+				pt.Prices[i].Overridden = true
+				found = true
+			}
+		}
+
+		if !found {
+			pt.Prices = append(pt.Prices, SeatPrice{
+				SeatType:   override.SeatType,
+				Price:      override.Price,
+				Overridden: true,
+			})
+		}
+	}
+
+	return pt
+}
+
+func GetTimeSlotPrices(tx *gorm.DB, timeSlotIDs []uuid.UUID) ([]TimeSlotPrice, error) {
+	var prices []TimeSlotPrice
+
+	if err := tx.Where("time_slot_prices.time_slot_id IN ?", timeSlotIDs).Order("time_slot_prices.seat_type").Find(&prices).Error; err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+// ResolveTimeSlotPrices resolves the price tables of time slots from the price
+// lists of their theaters and their overrides. Time slots of theaters without
+// a price list only list their overridden prices, if they have any.
+func ResolveTimeSlotPrices(tx *gorm.DB, timeSlots []TimeSlot) (map[uuid.UUID]PriceTable, error) {
+	tables := map[uuid.UUID]PriceTable{}
+	if len(timeSlots) == 0 {
+		return tables, nil
+	}
+
+	timeSlotIDs := []uuid.UUID{}
+	roomIDs := []uuid.UUID{}
+	for _, timeSlot := range timeSlots {
+		timeSlotIDs = append(timeSlotIDs, timeSlot.ID)
+		roomIDs = append(roomIDs, timeSlot.RoomID)
+	}
+
+	var rooms []Room
+	if err := tx.Unscoped().Where("rooms.id IN ?", roomIDs).Find(&rooms).Error; err != nil {
+		return nil, err
+	}
+
+	theaterIDs := []uuid.UUID{}
+	roomsByID := map[uuid.UUID]Room{}
+	for _, room := range rooms {
+		theaterIDs = append(theaterIDs, room.TheaterID)
+		roomsByID[room.ID] = room
+	}
+
+	var priceLists []PriceList
+	if err := tx.Where("price_lists.theater_id IN ?", theaterIDs).Scopes(preloadOrderedTimeOfDaySurchargesScope).Find(&priceLists).Error; err != nil {
+		return nil, err
+	}
+
+	priceListsByTheater := map[uuid.UUID]PriceList{}
+	for _, priceList := range priceLists {
+		priceListsByTheater[priceList.TheaterID] = priceList
+	}
+
+	prices, err := GetTimeSlotPrices(tx, timeSlotIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := map[uuid.UUID][]TimeSlotPrice{}
+	for _, price := range prices {
+		overrides[price.TimeSlotID] = append(overrides[price.TimeSlotID], price)
+	}
+
+	for _, timeSlot := range timeSlots {
+		room := roomsByID[timeSlot.RoomID]
+		priceList, ok := priceListsByTheater[room.TheaterID]
+		if ok {
+			tables[timeSlot.ID] = priceList.Resolve(room, timeSlot, overrides[timeSlot.ID])
+			continue
+		}
+
+		if len(overrides[timeSlot.ID]) > 0 {
+			table := PriceTable{Currency: DefaultCurrency, Prices: []SeatPrice{}}
+			tables[timeSlot.ID] = table.override(overrides[timeSlot.ID])
+		}
+	}
+
+	return tables, nil
+}
+
+// OverridePrices replaces the overridden prices of the time slot
+func (ts *TimeSlot) OverridePrices(tx *gorm.DB, prices []TimeSlotPrice) error {
+	if err := ts.ClearPriceOverrides(tx); err != nil {
+		return err
+	}
+
+	for i := range prices {
+		prices[i].TimeSlotID = ts.ID
+	}
+
+	if len(prices) > 0 {
+		if err := tx.Create(&prices).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ts *TimeSlot) ClearPriceOverrides(tx *gorm.DB) error {
+	if err := tx.Where("time_slot_id = ?", ts.ID).Delete(&TimeSlotPrice{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/request"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TicketType string
+
+const (
+	AdultTicket   TicketType = "ADULT"
+	StudentTicket TicketType = "STUDENT"
+	ChildTicket   TicketType = "CHILD"
+	SeniorTicket  TicketType = "SENIOR"
+)
+
+type DiscountType string
+
+const (
+	// Takes a percentage off the price
+	PercentDiscount DiscountType = "PERCENT"
+	// Takes an amount in cents off the price
+	AmountDiscount DiscountType = "AMOUNT"
+	// Sells tickets for a price in cents, unless they are cheaper already
+	FixedPriceDiscount DiscountType = "FIXED_PRICE"
+)
+
+// Promotion discounts the tickets of time slots that meet all of its
+// conditions, conditions that are empty match every time slot
+type Promotion struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Name string
+
+	// ISO weekdays of the start of the time slot, Monday = 1 ... Sunday = 7
+	Weekdays []int `gorm:"serializer:json"`
+	// Time of day in UTC that time slots start at or after
+	StartAfter *string
+	// Time of day in UTC that time slots start before
+	StartBefore *string
+	MovieIDs    []uuid.UUID  `gorm:"serializer:json"`
+	TheaterIDs  []uuid.UUID  `gorm:"serializer:json"`
+	TicketTypes []TicketType `gorm:"serializer:json"`
+	// First and last day of time slots the promotion is valid for
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+
+	DiscountType  DiscountType
+	DiscountValue int
+}
+
+func (p *Promotion) Create(tx *gorm.DB) error {
+	if err := tx.Create(p).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *Promotion) Save(tx *gorm.DB) error {
+	if err := tx.Save(p).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetPromotions(tx *gorm.DB, pagination *request.PaginationOptions, sort *request.SortOptions) ([]Promotion, int, error) {
+	var promotions []Promotion
+
+	query := tx.Model(&Promotion{}).Session(&gorm.Session{})
+
+	if err := query.Scopes(request.PaginateScope(pagination), request.SortScope(sort)).Order("promotions.name").Find(&promotions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return promotions, int(total), nil
+}
+
+func GetPromotion(tx *gorm.DB, id uuid.UUID) (Promotion, error) {
+	promotion := Promotion{
+		ID: id,
+	}
+
+	if err := tx.Where(&promotion).First(&promotion).Error; err != nil {
+		return promotion, err
+	}
+
+	return promotion, nil
+}
+
+func DeletePromotion(tx *gorm.DB, id uuid.UUID) error {
+	promotion, err := GetPromotion(tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Delete(&promotion).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// Matches reports whether the promotion applies to tickets of the type for the
+// time slot in a room of the theater
+func (p *Promotion) Matches(timeSlot TimeSlot, theaterID uuid.UUID, ticketType TicketType) bool {
+	start := timeSlot.StartTime.UTC()
+	day := start.Truncate(durationDay)
+
+	if p.ValidFrom != nil && day.Before(p.ValidFrom.Truncate(durationDay)) {
+		return false
+	}
+	if p.ValidUntil != nil && day.After(p.ValidUntil.Truncate(durationDay)) {
+		return false
+	}
+
+	weekday := int(start.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	if len(p.Weekdays) > 0 && !slices.Contains(p.Weekdays, weekday) {
+		return false
+	}
+
+	timeOfDay := start.Format("15:04")
+	if p.StartAfter != nil && timeOfDay < *p.StartAfter {
+		return false
+	}
+	if p.StartBefore != nil && timeOfDay >= *p.StartBefore {
+		return false
+	}
+
+	if len(p.MovieIDs) > 0 && !slices.Contains(p.MovieIDs, timeSlot.MovieID) {
+		return false
+	}
+	if len(p.TheaterIDs) > 0 && !slices.Contains(p.TheaterIDs, theaterID) {
+		return false
+	}
+	if len(p.TicketTypes) > 0 && !slices.Contains(p.TicketTypes, ticketType) {
+		return false
+	}
+
+	return true
+}
+
+// Apply returns the discounted price, which is never negative
+func (p *Promotion) Apply(price int) int {
+	switch p.DiscountType {
+	case PercentDiscount:
+		return max(0, price-price*p.DiscountValue/100)
+	case AmountDiscount:
+		return max(0, price-p.DiscountValue)
+	case FixedPriceDiscount:
+		return min(price, p.DiscountValue)
+	default:
+		return price
+	}
+}
+
+// GetApplicablePromotions returns the promotions that apply to tickets of the
+// type for the time slot, ordered by name
+func GetApplicablePromotions(tx *gorm.DB, timeSlot TimeSlot, theaterID uuid.UUID, ticketType TicketType) ([]Promotion, error) {
+	var promotions []Promotion
+
+	day := timeSlot.StartTime.UTC().Truncate(durationDay)
+	query := tx.Where("promotions.valid_from IS NULL OR promotions.valid_from <= ?", day).
+		Where("promotions.valid_until IS NULL OR promotions.valid_until >= ?", day)
+
+	if err := query.Order("promotions.name").Order("promotions.id").Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+
+	applicable := []Promotion{}
+	for _, promotion := range promotions {
+		if promotion.Matches(timeSlot, theaterID, ticketType) {
+			applicable = append(applicable, promotion)
+		}
+	}
+
+	return applicable, nil
+}
+
+// PromotedPrice is the price of a seat type after the best promotion, if any
+// promotion lowers it
+type PromotedPrice struct {
+	SeatType  SeatType
+	BasePrice int
+	Price     int
+	Promotion *Promotion
+}
+
+// ApplyPromotions picks the promotion that gives the lowest price for every
+// seat type of the price table, promotions are never combined
+func ApplyPromotions(table PriceTable, promotions []Promotion) []PromotedPrice {
+	prices := []PromotedPrice{}
+
+	for _, seatPrice := range table.Prices {
+		price := PromotedPrice{
+			SeatType:  seatPrice.SeatType,
+			BasePrice: seatPrice.Price,
+			Price:     seatPrice.Price,
+		}
+
+		for i := range promotions {
+			discounted := promotions[i].Apply(seatPrice.Price)
+			if discounted < price.Price {
+				price.Price = discounted
+				price.Promotion = &promotions[i]
+			}
+		}
+
+		prices = append(prices, price)
+	}
+
+	return prices
+}
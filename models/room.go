@@ -20,6 +20,13 @@ const (
 	All      RoomOperatingMode = "ALL"
 )
 
+type RoomScheduleMode string
+
+const (
+	AutomaticSchedule RoomScheduleMode = "AUTOMATIC"
+	TemplateSchedule  RoomScheduleMode = "TEMPLATE"
+)
+
 type Room struct {
 	ID        uuid.UUID
 	CreatedAt time.Time
@@ -28,14 +35,27 @@ type Room struct {
 	Name    string
 	Rows    int
 	Columns int
+	// Number of usable seats, kept up to date whenever the seats change
+	Capacity int
+	// Features of the room that are surcharged by the price list
+	ThreeD bool
+	IMAX   bool
 
 	OperatingMode RoomOperatingMode
 	OpeningHour   int
 	ClosingHour   int
 
-	TheaterID uuid.UUID
-	Theater   Theater    `gorm:"foreignKey:TheaterID" json:"-"`
-	TimeSlots []TimeSlot `gorm:"foreignKey:RoomID" json:"-"`
+	ScheduleMode RoomScheduleMode
+	// Start of the first day that has not been populated yet
+	ScheduledUntil *time.Time
+
+	DeletedAt gorm.DeletedAt
+
+	TheaterID         uuid.UUID
+	Theater           Theater            `gorm:"foreignKey:TheaterID" json:"-"`
+	TimeSlots         []TimeSlot         `gorm:"foreignKey:RoomID" json:"-"`
+	ScheduleTemplates []ScheduleTemplate `gorm:"foreignKey:RoomID" json:"-"`
+	Seats             []Seat             `gorm:"foreignKey:RoomID" json:"-"`
 }
 
 func (ts *Room) Create(tx *gorm.DB) error {
@@ -52,12 +72,12 @@ func (ts *Room) Save(tx *gorm.DB) error {
 	return nil
 }
 
-func GetTheaterRooms(tx *gorm.DB, theaterID uuid.UUID, pagination *request.PaginationOptions, sort *request.SortOptions) ([]Room, int, error) {
+func GetTheaterRooms(tx *gorm.DB, theaterID uuid.UUID, pagination *request.PaginationOptions, sort *request.SortOptions, scopes ...func(*gorm.DB) *gorm.DB) ([]Room, int, error) {
 	var rooms []Room
 
 	query := tx.Model(&Room{}).Where("rooms.theater_id = ?", theaterID).Session(&gorm.Session{})
 
-	if err := query.Scopes(request.PaginateScope(pagination), request.SortScope(sort), PreloadOrderedTimeSlotsScope).Find(&rooms).Error; err != nil {
+	if err := query.Scopes(request.PaginateScope(pagination), request.SortScope(sort)).Scopes(scopes...).Find(&rooms).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -69,31 +89,69 @@ func GetTheaterRooms(tx *gorm.DB, theaterID uuid.UUID, pagination *request.Pagin
 	return rooms, int(total), nil
 }
 
-func GetRoom(tx *gorm.DB, theaterID, roomID uuid.UUID) (Room, error) {
+func GetRoom(tx *gorm.DB, theaterID, roomID uuid.UUID, scopes ...func(*gorm.DB) *gorm.DB) (Room, error) {
 	room := Room{
 		ID:        roomID,
 		TheaterID: theaterID,
 	}
 
-	if err := tx.Where(&room).Scopes(PreloadOrderedTimeSlotsScope).First(&room).Error; err != nil {
+	if err := tx.Where(&room).Scopes(scopes...).First(&room).Error; err != nil {
 		return room, err
 	}
 
 	return room, nil
 }
 
-func DeleteRoom(tx *gorm.DB, theaterID, id uuid.UUID) error {
+const (
+	RoomDeletedReason    = "Room was deleted"
+	TheaterDeletedReason = "Theater was deleted"
+)
+
+// DeleteRoom cancels the future time slots of the room and removes it, while
+// the time slots that already took place are kept for history. Unless forced,
+// rooms with reservations for future time slots are not deleted and the
+// reserved time slots are reported in a conflict error instead.
+func DeleteRoom(tx *gorm.DB, theaterID, id uuid.UUID, force bool) error {
+	return deleteRoom(tx, theaterID, id, RoomDeletedReason, force)
+}
+
+func deleteRoom(tx *gorm.DB, theaterID, id uuid.UUID, reason string, force bool) error {
 	room := Room{
 		ID:        id,
 		TheaterID: theaterID,
 	}
 
-	if err := tx.Where(&room).Scopes(PreloadOrderedTimeSlotsScope).First(&room).Error; err != nil {
+	if err := tx.Where(&room).Scopes(PreloadOrderedTimeSlotsScope).Preload("ScheduleTemplates").First(&room).Error; err != nil {
 		return err
 	}
 
+	now := time.Now()
+
+	if !force {
+		reserved, err := GetReservedTimeSlots(tx, room.ID, now)
+		if err != nil {
+			return err
+		}
+
+		err = reservedTimeSlotsConflict(reserved, "Room has reservations for future time slots")
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, timeslot := range room.TimeSlots {
-		err := DeleteTimeSlot(tx, id, timeslot.ID)
+		if timeslot.Status != ScheduledTimeSlot || !timeslot.StartTime.After(now) {
+			continue
+		}
+
+		err := timeslot.Cancel(tx, reason, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, template := range room.ScheduleTemplates {
+		err := DeleteScheduleTemplate(tx, id, template.ID)
 		if err != nil {
 			return err
 		}
@@ -105,14 +163,19 @@ func DeleteRoom(tx *gorm.DB, theaterID, id uuid.UUID) error {
 	return nil
 }
 
+// PreloadOrderedTimeSlotsScope preloads the time slots of rooms that are not
+// cancelled in order of start, for scheduling that needs to fit around them
 func PreloadOrderedTimeSlotsScope(db *gorm.DB) *gorm.DB {
 	return db.Preload("TimeSlots", func(db *gorm.DB) *gorm.DB {
-		return db.Order("time_slots.start_time")
+		return db.Where("time_slots.status <> ?", CancelledTimeSlot).Order("time_slots.start_time")
 	})
 }
 
 const durationDay = time.Hour * 24
 
+// Refilled time slots start on a multiple of this duration
+const refillAlignment = time.Minute * 10
+
 func (r *Room) GetTimes(day time.Time) (openingTime time.Time, closingTime time.Time) {
 	baseDayTime := day.Truncate(durationDay)
 	openingTime = baseDayTime.Add(time.Hour * time.Duration(r.OpeningHour))
@@ -120,6 +183,21 @@ func (r *Room) GetTimes(day time.Time) (openingTime time.Time, closingTime time.
 	return
 }
 
+// OperatesOn reports whether the operating mode of the room opens it on the
+// weekday of the day
+func (r *Room) OperatesOn(day time.Time) bool {
+	switch r.OperatingMode {
+	case Weekdays:
+		return isoWeekday(day) <= 5
+	case Weekends:
+		return isoWeekday(day) >= 6
+	case All:
+		return true
+	default:
+		return false
+	}
+}
+
 type TimeSlotGap struct {
 	room  *Room
 	start time.Time
@@ -135,6 +213,14 @@ func (r *Room) GetTimeSlotGapsForDay(day time.Time) []TimeSlotGap {
 			break
 		}
 
+		// Only timeslots overlapping the rest of the day are relevant
+		if !timeslot.EndTime.After(startTime) {
+			continue
+		}
+		if !timeslot.StartTime.Before(closingTime) {
+			break
+		}
+
 		if !timeslot.CoversInstant(startTime) {
 			gaps = append(gaps, TimeSlotGap{
 				room:  r,
@@ -155,15 +241,16 @@ func (r *Room) GetTimeSlotGapsForDay(day time.Time) []TimeSlotGap {
 	return gaps
 }
 
-func (tsg *TimeSlotGap) Populate(tx *gorm.DB, movies []Movie) error {
+func (tsg *TimeSlotGap) Populate(tx *gorm.DB, movies []Movie, weights MovieWeights) ([]TimeSlot, error) {
 	slog.Debug("Populating time gap", "start", tsg.start, "end", tsg.end)
+	created := []TimeSlot{}
 	startTime := tsg.start
 	for startTime.Before(tsg.end) {
 		remainingMinutes := int(math.Floor(tsg.end.Sub(startTime).Minutes()))
 
 		possibleMovies := slices.Collect(func(yield func(Movie) bool) {
 			for _, movie := range movies {
-				if !movie.Active {
+				if !movie.IsScreenable(startTime) {
 					continue
 				}
 				if movie.LengthMinutes <= remainingMinutes {
@@ -181,7 +268,7 @@ func (tsg *TimeSlotGap) Populate(tx *gorm.DB, movies []Movie) error {
 			break
 		}
 
-		selectedMovie := WeighedSelectMovie(possibleMovies)
+		selectedMovie := WeighedSelectMovie(possibleMovies, weights)
 		slog.Debug("Selected movie", "title", selectedMovie.Title)
 		calculatedEndTime := selectedMovie.CalculateEndTime(startTime)
 
@@ -195,23 +282,188 @@ func (tsg *TimeSlotGap) Populate(tx *gorm.DB, movies []Movie) error {
 
 		err := timeSlot.Create(tx)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		created = append(created, timeSlot)
 
 		startTime = calculatedEndTime
 	}
 
 	slog.Debug("Finished populating time gap", "start", tsg.start, "end", tsg.end)
+	return created, nil
+}
+
+// freedBy returns the parts of the gap that the time slots used to occupy,
+// adjacent time slots free up a single part
+func (tsg *TimeSlotGap) freedBy(timeSlots []TimeSlot) []TimeSlotGap {
+	sorted := slices.Clone(timeSlots)
+	slices.SortFunc(sorted, func(a, b TimeSlot) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+
+	parts := []TimeSlotGap{}
+	for _, timeSlot := range sorted {
+		start := timeSlot.StartTime
+		if start.Before(tsg.start) {
+			start = tsg.start
+		}
+		end := timeSlot.EndTime
+		if end.After(tsg.end) {
+			end = tsg.end
+		}
+		if !start.Before(end) {
+			continue
+		}
+
+		if len(parts) > 0 && !start.After(parts[len(parts)-1].end) {
+			last := &parts[len(parts)-1]
+			if end.After(last.end) {
+				last.end = end
+			}
+			continue
+		}
+
+		parts = append(parts, TimeSlotGap{
+			room:  tsg.room,
+			start: start,
+			end:   end,
+		})
+	}
+
+	return parts
+}
+
+// RefillDay populates the parts of the gaps of the day that the freed time
+// slots used to occupy and that have not started yet, gaps that were empty
+// before are left as they are
+func (r *Room) RefillDay(tx *gorm.DB, day time.Time, freed []TimeSlot, now time.Time, movies []Movie, weights MovieWeights) ([]TimeSlot, error) {
+	created := []TimeSlot{}
+	earliestStart := now.Truncate(refillAlignment).Add(refillAlignment)
+
+	for _, gap := range r.GetTimeSlotGapsForDay(day) {
+		for _, part := range gap.freedBy(freed) {
+			if part.start.Before(earliestStart) {
+				part.start = earliestStart
+			}
+			if !part.start.Before(part.end) {
+				continue
+			}
+
+			timeSlots, err := part.Populate(tx, movies, weights)
+			if err != nil {
+				return nil, err
+			}
+			created = append(created, timeSlots...)
+		}
+	}
+
+	return created, nil
+}
+
+func (r *Room) addTimeSlot(timeSlot TimeSlot) {
+	r.TimeSlots = append(r.TimeSlots, timeSlot)
+	slices.SortFunc(r.TimeSlots, func(a, b TimeSlot) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+}
+
+func (r *Room) overlappingTimeSlot(start, end time.Time) (TimeSlot, bool) {
+	for _, timeslot := range r.TimeSlots {
+		if timeslot.Overlaps(start, end) {
+			return timeslot, true
+		}
+	}
+	return TimeSlot{}, false
+}
+
+func (r *Room) isOccupied(start, end time.Time) bool {
+	_, occupied := r.overlappingTimeSlot(start, end)
+	return occupied
+}
+
+func (r *Room) MaterializeScheduleTemplates(tx *gorm.DB, day time.Time, templates []ScheduleTemplate, movies []Movie, weights MovieWeights) error {
+	openingTime, closingTime := r.GetTimes(day)
+
+	dayTemplates := []ScheduleTemplate{}
+	for _, template := range templates {
+		if template.AppliesTo(day) {
+			dayTemplates = append(dayTemplates, template)
+		}
+	}
+	slices.SortFunc(dayTemplates, func(a, b ScheduleTemplate) int {
+		return a.StartTimeOn(day).Compare(b.StartTimeOn(day))
+	})
+
+	for i, template := range dayTemplates {
+		startTime := template.StartTimeOn(day)
+		if startTime.Before(openingTime) || !startTime.Before(closingTime) {
+			slog.Debug("Skipping template outside of operating hours", "template", template.ID, "startTime", startTime)
+			continue
+		}
+
+		limit := closingTime
+		if i+1 < len(dayTemplates) {
+			nextStartTime := dayTemplates[i+1].StartTimeOn(day)
+			if nextStartTime.Before(limit) {
+				limit = nextStartTime
+			}
+		}
+
+		movie, ok := template.selectMovie(movies, weights, startTime, limit)
+		if !ok {
+			slog.Debug("No movie available for template", "template", template.ID, "startTime", startTime)
+			continue
+		}
+
+		endTime := movie.CalculateEndTime(startTime)
+		if endTime.After(closingTime) || r.isOccupied(startTime, endTime) {
+			slog.Debug("Template slot is not available", "template", template.ID, "startTime", startTime)
+			continue
+		}
+
+		timeSlot := TimeSlot{
+			ID:        uuid.New(),
+			StartTime: startTime,
+			EndTime:   endTime,
+			RoomID:    r.ID,
+			MovieID:   movie.ID,
+		}
+
+		err := timeSlot.Create(tx)
+		if err != nil {
+			return err
+		}
+
+		r.addTimeSlot(timeSlot)
+	}
+
 	return nil
 }
 
-func (r *Room) PopulateRoom(tx *gorm.DB, now time.Time, days int, movies []Movie) error {
+func (r *Room) PopulateRoom(tx *gorm.DB, now time.Time, days int, movies []Movie, weights MovieWeights) error {
+	var templates []ScheduleTemplate
+	if r.ScheduleMode == TemplateSchedule {
+		var err error
+		templates, _, err = GetRoomScheduleTemplates(tx, r.ID, nil, nil)
+		if err != nil {
+			return err
+		}
+	}
+
 	for day := range days {
 		slog.Debug("Refreshing timeslots", "room", r.ID, "day", day)
 		baseDayTime := now.Add(durationDay * time.Duration(day))
+
+		if r.ScheduleMode == TemplateSchedule {
+			err := r.MaterializeScheduleTemplates(tx, baseDayTime, templates, movies, weights)
+			if err != nil {
+				return err
+			}
+		}
+
 		gaps := r.GetTimeSlotGapsForDay(baseDayTime)
 		for _, gap := range gaps {
-			err := gap.Populate(tx, movies)
+			_, err := gap.Populate(tx, movies, weights)
 			if err != nil {
 				return err
 			}
@@ -219,6 +471,40 @@ func (r *Room) PopulateRoom(tx *gorm.DB, now time.Time, days int, movies []Movie
 		slog.Debug("Finished refreshing timeslots", "room", r.ID, "day", day)
 	}
 
+	return r.markScheduledUntil(tx, now.Truncate(durationDay).Add(durationDay*time.Duration(days)))
+}
+
+// ExtendRoom populates only the days between the end of the already populated
+// schedule and the horizon, leaving earlier days untouched
+func (r *Room) ExtendRoom(tx *gorm.DB, now time.Time, days int, movies []Movie, weights MovieWeights) error {
+	from := now.Truncate(durationDay)
+	horizon := from.Add(durationDay * time.Duration(days))
+
+	if r.ScheduledUntil != nil && r.ScheduledUntil.After(from) {
+		from = *r.ScheduledUntil
+	}
+
+	if !from.Before(horizon) {
+		slog.Debug("Room already scheduled until horizon", "room", r.ID, "horizon", horizon)
+		return nil
+	}
+
+	newDays := int(horizon.Sub(from) / durationDay)
+	slog.Debug("Extending timeslots", "room", r.ID, "from", from, "days", newDays)
+
+	return r.PopulateRoom(tx, from, newDays, movies, weights)
+}
+
+func (r *Room) markScheduledUntil(tx *gorm.DB, until time.Time) error {
+	if r.ScheduledUntil != nil && !until.After(*r.ScheduledUntil) {
+		return nil
+	}
+
+	if err := tx.Model(r).UpdateColumn("scheduled_until", until).Error; err != nil {
+		return err
+	}
+
+	r.ScheduledUntil = &until
 	return nil
 }
 
@@ -0,0 +1,253 @@
+package models
+
+import (
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ScheduleChanges summarizes how existing time slots were affected by a change
+type ScheduleChanges struct {
+	// Time slots whose end time was recalculated
+	Updated []TimeSlot
+	// Time slots that were moved to make room for a preceding time slot
+	Reflowed []TimeSlot
+	// Time slots that could not be moved after a preceding time slot, would
+	// overlap a locked time slot or would end after closing time and were
+	// cancelled
+	Conflicts []TimeSlot
+	// Time slots that were cancelled
+	Cancelled []TimeSlot
+	// Time slots that were added to fill the gaps of cancelled time slots
+	Created []TimeSlot
+}
+
+func NewScheduleChanges() ScheduleChanges {
+	return ScheduleChanges{
+		Updated:   []TimeSlot{},
+		Reflowed:  []TimeSlot{},
+		Conflicts: []TimeSlot{},
+		Cancelled: []TimeSlot{},
+		Created:   []TimeSlot{},
+	}
+}
+
+// CascadeLength recalculates the end time of every future time slot of the
+// movie and reflows the following time slots in the same room
+func (m *Movie) CascadeLength(tx *gorm.DB, now time.Time) (ScheduleChanges, error) {
+	changes := NewScheduleChanges()
+
+	var roomIDs []uuid.UUID
+	if err := tx.Model(&TimeSlot{}).Where("movie_id = ? AND status = ? AND start_time > ?", m.ID, ScheduledTimeSlot, now).Distinct().Pluck("room_id", &roomIDs).Error; err != nil {
+		return changes, err
+	}
+
+	for _, roomID := range roomIDs {
+		var room Room
+		if err := tx.Where(&Room{ID: roomID}).First(&room).Error; err != nil {
+			return changes, err
+		}
+
+		var timeSlots []TimeSlot
+		if err := tx.Where("room_id = ? AND status = ? AND start_time > ?", roomID, ScheduledTimeSlot, now).Order("start_time").Find(&timeSlots).Error; err != nil {
+			return changes, err
+		}
+
+		err := room.reflowTimeSlots(tx, timeSlots, m, &changes)
+		if err != nil {
+			return changes, err
+		}
+	}
+
+	return changes, nil
+}
+
+const (
+	ReflowConflictReason = "Could not be moved after a rescheduled time slot"
+	ReflowClosingReason  = "Would end after closing time of the room"
+)
+
+type reflowOutcome int
+
+const (
+	reflowUnchanged reflowOutcome = iota
+	reflowUpdated
+	reflowMoved
+	reflowConflict
+	reflowPastClosing
+)
+
+// reflowTimeSlots recalculates the time slots of the movie and pushes back
+// every following time slot that now overlaps its predecessor. Time slots that
+// would end past closing time, whether pushed back or lengthened, are cancelled
+// and reported. Locked time slots are never moved, instead the time slots
+// that would now overlap them are cancelled and reported.
+func (r *Room) reflowTimeSlots(tx *gorm.DB, timeSlots []TimeSlot, movie *Movie, changes *ScheduleChanges) error {
+	var previousEnd time.Time
+	outcomes := make([]reflowOutcome, len(timeSlots))
+	original := slices.Clone(timeSlots)
+
+	for i := range timeSlots {
+		timeSlot := &timeSlots[i]
+
+		startTime := timeSlot.StartTime
+		if startTime.Before(previousEnd) {
+			startTime = previousEnd
+		}
+
+		endTime := timeSlot.EndTime.Add(startTime.Sub(timeSlot.StartTime))
+		if timeSlot.MovieID == movie.ID {
+			endTime = movie.CalculateEndTime(startTime)
+		}
+
+		moved := !startTime.Equal(timeSlot.StartTime)
+		if moved && timeSlot.Locked {
+			slog.Debug("Locked time slot can not be reflowed", "timeslot", timeSlot.ID, "startTime", startTime)
+			for j := i - 1; j >= 0; j-- {
+				if outcomes[j] != reflowUpdated && outcomes[j] != reflowMoved {
+					continue
+				}
+				if !timeSlots[j].EndTime.After(timeSlot.StartTime) {
+					break
+				}
+				timeSlots[j] = original[j]
+				outcomes[j] = reflowConflict
+			}
+			previousEnd = timeSlot.EndTime
+			continue
+		}
+		if !endTime.Equal(timeSlot.EndTime) {
+			_, closingTime := r.GetTimes(timeSlot.StartTime)
+			if endTime.After(closingTime) {
+				slog.Debug("Time slot can not be reflowed", "timeslot", timeSlot.ID, "startTime", startTime, "endTime", endTime)
+				outcomes[i] = reflowConflict
+				if !moved {
+					outcomes[i] = reflowPastClosing
+				}
+				continue
+			}
+		}
+
+		previousEnd = endTime
+		if !moved && endTime.Equal(timeSlot.EndTime) {
+			continue
+		}
+
+		timeSlot.StartTime = startTime
+		timeSlot.EndTime = endTime
+
+		if moved {
+			outcomes[i] = reflowMoved
+		} else {
+			outcomes[i] = reflowUpdated
+		}
+	}
+
+	// Time slots only ever move back, so saving them starting with the last one
+	// never overlaps a time slot that has not been saved yet
+	now := time.Now()
+	for i := len(timeSlots) - 1; i >= 0; i-- {
+		var err error
+		switch outcomes[i] {
+		case reflowConflict:
+			err = timeSlots[i].Cancel(tx, ReflowConflictReason, now)
+		case reflowPastClosing:
+			err = timeSlots[i].Cancel(tx, ReflowClosingReason, now)
+		case reflowUpdated, reflowMoved:
+			err = timeSlots[i].Save(tx)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, timeSlot := range timeSlots {
+		switch outcomes[i] {
+		case reflowUpdated:
+			changes.Updated = append(changes.Updated, timeSlot)
+		case reflowMoved:
+			changes.Reflowed = append(changes.Reflowed, timeSlot)
+		case reflowConflict, reflowPastClosing:
+			changes.Conflicts = append(changes.Conflicts, timeSlot)
+		}
+	}
+
+	return nil
+}
+
+const MovieDeactivatedReason = "Movie was deactivated"
+
+// CancelFutureTimeSlots cancels every unlocked time slot of the movie that has
+// not started yet and fills the time they occupied with the given movies.
+// Unless forced, time slots with reservations are not cancelled and are
+// reported in a conflict error instead.
+func (m *Movie) CancelFutureTimeSlots(tx *gorm.DB, now time.Time, movies []Movie, force bool) (ScheduleChanges, error) {
+	changes := NewScheduleChanges()
+
+	var timeSlots []TimeSlot
+	if err := tx.Where("movie_id = ? AND status = ? AND start_time > ? AND NOT locked", m.ID, ScheduledTimeSlot, now).Order("start_time").Find(&timeSlots).Error; err != nil {
+		return changes, err
+	}
+
+	if !force && len(timeSlots) > 0 {
+		timeSlotIDs := []uuid.UUID{}
+		for _, timeSlot := range timeSlots {
+			timeSlotIDs = append(timeSlotIDs, timeSlot.ID)
+		}
+
+		reserved, err := getReservedTimeSlots(tx, now, reservedTimeSlotIDsScope(timeSlotIDs))
+		if err != nil {
+			return changes, err
+		}
+
+		err = reservedTimeSlotsConflict(reserved, "Movie has reservations for future time slots")
+		if err != nil {
+			return changes, err
+		}
+	}
+
+	days := map[uuid.UUID][]time.Time{}
+	freed := map[uuid.UUID][]TimeSlot{}
+	for _, timeSlot := range timeSlots {
+		err := timeSlot.Cancel(tx, MovieDeactivatedReason, now)
+		if err != nil {
+			return changes, err
+		}
+		changes.Cancelled = append(changes.Cancelled, timeSlot)
+		freed[timeSlot.RoomID] = append(freed[timeSlot.RoomID], timeSlot)
+
+		day := timeSlot.StartTime.Truncate(durationDay)
+		if !slices.ContainsFunc(days[timeSlot.RoomID], day.Equal) {
+			days[timeSlot.RoomID] = append(days[timeSlot.RoomID], day)
+		}
+	}
+
+	for roomID, roomDays := range days {
+		var room Room
+		if err := tx.Where(&Room{ID: roomID}).Scopes(PreloadOrderedTimeSlotsScope).First(&room).Error; err != nil {
+			return changes, err
+		}
+
+		weights, err := theaterRoomWeights(tx, room, now)
+		if err != nil {
+			return changes, err
+		}
+
+		for _, day := range roomDays {
+			created, err := room.RefillDay(tx, day, freed[roomID], now, movies, weights)
+			if err != nil {
+				return changes, err
+			}
+			changes.Created = append(changes.Created, created...)
+		}
+	}
+
+	slices.SortFunc(changes.Created, func(a, b TimeSlot) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+
+	return changes, nil
+}
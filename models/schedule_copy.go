@@ -0,0 +1,109 @@
+package models
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	CopySkipMovieInactive        = "Movie is inactive"
+	CopySkipMovieOutsideOfWindow = "Movie is outside of its release window"
+	CopySkipMovieMissing         = "Movie no longer exists"
+	CopySkipRoomClosed           = "Room is closed on that day"
+	CopySkipOutsideOfHours       = "Time slot is outside of the opening hours of the room"
+)
+
+type TimeSlotCopySkip struct {
+	Source TimeSlot
+	Reason string
+}
+
+type TimeSlotCopyConflict struct {
+	Source   TimeSlot
+	Existing TimeSlot
+}
+
+type TimeSlotCopyResult struct {
+	Created   []TimeSlot
+	Skipped   []TimeSlotCopySkip
+	Conflicts []TimeSlotCopyConflict
+}
+
+// CopyTimeSlots copies every time slot of the room starting in [from, to) to
+// the same time shifted by the given duration. Copies are not created when
+// the movie can no longer be screened, the room is not open at the target time
+// or the target time is already occupied.
+// In dry run mode nothing is written, but the result reports what would be.
+func (r *Room) CopyTimeSlots(tx *gorm.DB, from, to time.Time, shift time.Duration, movies []Movie, dryRun bool, result *TimeSlotCopyResult) error {
+	moviesByID := map[uuid.UUID]Movie{}
+	for _, movie := range movies {
+		moviesByID[movie.ID] = movie
+	}
+
+	sources := []TimeSlot{}
+	for _, timeSlot := range r.TimeSlots {
+		if !timeSlot.StartTime.Before(from) && timeSlot.StartTime.Before(to) {
+			sources = append(sources, timeSlot)
+		}
+	}
+
+	for _, source := range sources {
+		startTime := source.StartTime.Add(shift)
+
+		movie, ok := moviesByID[source.MovieID]
+		if !ok {
+			result.Skipped = append(result.Skipped, TimeSlotCopySkip{Source: source, Reason: CopySkipMovieMissing})
+			continue
+		}
+		if !movie.Active {
+			result.Skipped = append(result.Skipped, TimeSlotCopySkip{Source: source, Reason: CopySkipMovieInactive})
+			continue
+		}
+		if !movie.InReleaseWindow(startTime) {
+			result.Skipped = append(result.Skipped, TimeSlotCopySkip{Source: source, Reason: CopySkipMovieOutsideOfWindow})
+			continue
+		}
+
+		endTime := movie.CalculateEndTime(startTime)
+
+		if !r.OperatesOn(startTime) {
+			result.Skipped = append(result.Skipped, TimeSlotCopySkip{Source: source, Reason: CopySkipRoomClosed})
+			continue
+		}
+		openingTime, closingTime := r.GetTimes(startTime)
+		if startTime.Before(openingTime) || endTime.After(closingTime) {
+			result.Skipped = append(result.Skipped, TimeSlotCopySkip{Source: source, Reason: CopySkipOutsideOfHours})
+			continue
+		}
+
+		existing, occupied := r.overlappingTimeSlot(startTime, endTime)
+		if occupied {
+			result.Conflicts = append(result.Conflicts, TimeSlotCopyConflict{Source: source, Existing: existing})
+			continue
+		}
+
+		timeSlot := TimeSlot{
+			ID:        uuid.New(),
+			StartTime: startTime,
+			EndTime:   endTime,
+			RoomID:    r.ID,
+			MovieID:   movie.ID,
+		}
+
+		if !dryRun {
+			err := timeSlot.Create(tx)
+			if err != nil {
+				return err
+			}
+		}
+
+		slog.Debug("Copied time slot", "room", r.ID, "source", source.ID, "startTime", startTime, "dryRun", dryRun)
+		r.addTimeSlot(timeSlot)
+		result.Created = append(result.Created, timeSlot)
+	}
+
+	return nil
+}
@@ -0,0 +1,324 @@
+package models
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/request"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ScheduleRunKind string
+
+const (
+	PopulateScheduleRun ScheduleRunKind = "POPULATE"
+	ExtendScheduleRun   ScheduleRunKind = "EXTEND"
+)
+
+const (
+	// Only time slots that started within this many days count towards demand
+	demandLookbackDays = 28
+	// The occupancy of a time slot counts half as much after this many days
+	demandHalfLifeDays = 7
+
+	// Added to the occupancy of every movie, so that a handful of sold seats
+	// does not skew the weights of theaters with little history
+	demandSmoothing = 0.1
+
+	minDemandWeight = 0.25
+	maxDemandWeight = 4
+)
+
+// ScheduleRun records the movie weights that populating the schedule of a
+// theater was based on
+type ScheduleRun struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Kind ScheduleRunKind
+
+	TheaterID uuid.UUID
+	Theater   Theater           `gorm:"foreignKey:TheaterID" json:"-"`
+	Demands   []MovieDemand     `gorm:"foreignKey:ScheduleRunID" json:"-"`
+	Rooms     []ScheduleRunRoom `gorm:"foreignKey:ScheduleRunID" json:"-"`
+}
+
+// MovieDemand is the decayed average share of seats sold for the recent
+// screenings of a movie in a theater. The weight compares the smoothed
+// occupancy to the average of all movies, movies without screenings are not
+// listed and weigh 1.
+type MovieDemand struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Screenings int
+	Occupancy  float64
+	Weight     float64
+
+	ScheduleRunID uuid.UUID
+	ScheduleRun   ScheduleRun `gorm:"foreignKey:ScheduleRunID" json:"-"`
+	MovieID       uuid.UUID
+	Movie         Movie `gorm:"foreignKey:MovieID" json:"-"`
+}
+
+// ScheduleRunRoom records how the weights of a run were adjusted for a room.
+// The room used the weights of the run raised to the exponent, which follows
+// from its capacity compared to the largest room of the theater.
+type ScheduleRunRoom struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Capacity int
+	Exponent float64
+
+	ScheduleRunID uuid.UUID
+	ScheduleRun   ScheduleRun `gorm:"foreignKey:ScheduleRunID" json:"-"`
+	RoomID        uuid.UUID
+	Room          Room `gorm:"foreignKey:RoomID" json:"-"`
+}
+
+// GetMovieDemands computes the demand of every movie screened in the theater
+// within the lookback period before now, strongest sellers first
+func GetMovieDemands(tx *gorm.DB, theaterID uuid.UUID, now time.Time) ([]MovieDemand, error) {
+	var timeSlots []TimeSlot
+	query := tx.Joins("JOIN rooms ON rooms.id = time_slots.room_id").
+		Where("rooms.theater_id = ?", theaterID).
+		Where("time_slots.status <> ?", CancelledTimeSlot).
+		Where("time_slots.start_time >= ? AND time_slots.end_time <= ?", now.AddDate(0, 0, -demandLookbackDays), now)
+	if err := query.Find(&timeSlots).Error; err != nil {
+		return nil, err
+	}
+
+	occupancies, err := ResolveTimeSlotOccupancies(tx, timeSlots, now)
+	if err != nil {
+		return nil, err
+	}
+
+	type accumulator struct {
+		screenings int
+		occupancy  float64
+		decay      float64
+	}
+	accumulators := map[uuid.UUID]*accumulator{}
+	for _, timeSlot := range timeSlots {
+		occupancy := occupancies[timeSlot.ID]
+		if occupancy.Capacity == 0 {
+			continue
+		}
+
+		ageDays := now.Sub(timeSlot.StartTime).Hours() / 24
+		decay := math.Pow(0.5, ageDays/demandHalfLifeDays)
+
+		acc, ok := accumulators[timeSlot.MovieID]
+		if !ok {
+			acc = &accumulator{}
+			accumulators[timeSlot.MovieID] = acc
+		}
+		acc.screenings++
+		acc.occupancy += decay * math.Min(1, float64(occupancy.Sold)/float64(occupancy.Capacity))
+		acc.decay += decay
+	}
+
+	demands := []MovieDemand{}
+	total := 0.0
+	for movieID, acc := range accumulators {
+		demand := MovieDemand{
+			MovieID:    movieID,
+			Screenings: acc.screenings,
+			Occupancy:  acc.occupancy / acc.decay,
+		}
+		total += demand.Occupancy
+		demands = append(demands, demand)
+	}
+
+	for i := range demands {
+		average := total / float64(len(demands))
+		weight := (demands[i].Occupancy + demandSmoothing) / (average + demandSmoothing)
+		weight = math.Max(minDemandWeight, math.Min(maxDemandWeight, weight))
+		demands[i].Occupancy = roundToPrecision(demands[i].Occupancy, 3)
+		demands[i].Weight = roundToPrecision(weight, 3)
+	}
+
+	slices.SortFunc(demands, func(a, b MovieDemand) int {
+		if a.Weight != b.Weight {
+			if a.Weight > b.Weight {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.MovieID.String(), b.MovieID.String())
+	})
+
+	return demands, nil
+}
+
+// NewScheduleRun computes the current movie demand of the theater and records
+// it as a run of the given kind, together with the exponent of every room
+func (t *Theater) NewScheduleRun(tx *gorm.DB, kind ScheduleRunKind, now time.Time) (ScheduleRun, error) {
+	demands, err := GetMovieDemands(tx, t.ID, now)
+	if err != nil {
+		return ScheduleRun{}, err
+	}
+
+	rooms, _, err := GetTheaterRooms(tx, t.ID, nil, nil)
+	if err != nil {
+		return ScheduleRun{}, err
+	}
+
+	run := ScheduleRun{
+		ID:        uuid.New(),
+		Kind:      kind,
+		TheaterID: t.ID,
+	}
+
+	if err := tx.Omit("Demands", "Rooms").Create(&run).Error; err != nil {
+		return run, err
+	}
+
+	for _, demand := range demands {
+		demand.ID = uuid.New()
+		demand.ScheduleRunID = run.ID
+		if err := tx.Create(&demand).Error; err != nil {
+			return run, err
+		}
+		run.Demands = append(run.Demands, demand)
+	}
+
+	largest := largestCapacity(rooms)
+	for _, room := range rooms {
+		runRoom := ScheduleRunRoom{
+			ID:            uuid.New(),
+			Capacity:      room.Capacity,
+			Exponent:      roundToPrecision(roomWeightExponent(room.Capacity, largest), 3),
+			ScheduleRunID: run.ID,
+			RoomID:        room.ID,
+		}
+		if err := tx.Create(&runRoom).Error; err != nil {
+			return run, err
+		}
+		run.Rooms = append(run.Rooms, runRoom)
+	}
+
+	return run, nil
+}
+
+// Weights returns the movie weights of the run, before they are adjusted for
+// the rooms
+func (sr *ScheduleRun) Weights() MovieWeights {
+	weights := MovieWeights{}
+	for _, demand := range sr.Demands {
+		weights[demand.MovieID] = demand.Weight
+	}
+	return weights
+}
+
+// RoomWeights returns the movie weights the run populates the schedule of the
+// room with, rooms the run did not record use the weights unadjusted
+func (sr *ScheduleRun) RoomWeights(roomID uuid.UUID) MovieWeights {
+	for _, room := range sr.Rooms {
+		if room.RoomID == roomID {
+			return sr.Weights().Pow(room.Exponent)
+		}
+	}
+	return sr.Weights()
+}
+
+func preloadOrderedMovieDemandsScope(db *gorm.DB) *gorm.DB {
+	return db.Preload("Demands", func(db *gorm.DB) *gorm.DB {
+		return db.Order("movie_demands.weight DESC, movie_demands.movie_id")
+	})
+}
+
+func preloadOrderedScheduleRunRoomsScope(db *gorm.DB) *gorm.DB {
+	return db.Preload("Rooms", func(db *gorm.DB) *gorm.DB {
+		return db.Order("schedule_run_rooms.capacity DESC, schedule_run_rooms.room_id")
+	})
+}
+
+func GetTheaterScheduleRuns(tx *gorm.DB, theaterID uuid.UUID, pagination *request.PaginationOptions, sort *request.SortOptions) ([]ScheduleRun, int, error) {
+	var runs []ScheduleRun
+
+	query := tx.Model(&ScheduleRun{}).Where("schedule_runs.theater_id = ?", theaterID).Session(&gorm.Session{})
+
+	if err := query.Scopes(request.PaginateScope(pagination), request.SortScope(sort), preloadOrderedMovieDemandsScope, preloadOrderedScheduleRunRoomsScope).Order("created_at DESC, id").Find(&runs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return runs, int(total), nil
+}
+
+func GetScheduleRun(tx *gorm.DB, theaterID, id uuid.UUID) (ScheduleRun, error) {
+	run := ScheduleRun{
+		ID:        id,
+		TheaterID: theaterID,
+	}
+
+	if err := tx.Where(&run).Scopes(preloadOrderedMovieDemandsScope, preloadOrderedScheduleRunRoomsScope).First(&run).Error; err != nil {
+		return run, err
+	}
+
+	return run, nil
+}
+
+// theaterRoomWeights returns the current movie weights of the theater of the
+// room, without recording a run
+func theaterRoomWeights(tx *gorm.DB, room Room, now time.Time) (MovieWeights, error) {
+	demands, err := GetMovieDemands(tx, room.TheaterID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var rooms []Room
+	if err := tx.Where("rooms.theater_id = ?", room.TheaterID).Find(&rooms).Error; err != nil {
+		return nil, err
+	}
+
+	run := ScheduleRun{Demands: demands}
+	return run.Weights().Pow(roomWeightExponent(room.Capacity, largestCapacity(rooms))), nil
+}
+
+// MovieWeights scale the chance of a movie being picked by the scheduler,
+// movies without a weight weigh 1
+type MovieWeights map[uuid.UUID]float64
+
+func (w MovieWeights) Of(movieID uuid.UUID) float64 {
+	weight, ok := w[movieID]
+	if !ok {
+		return 1
+	}
+	return weight
+}
+
+// roomWeightExponent sharpens the weights in rooms close to the largest
+// capacity of the theater and flattens them in small rooms, so that strong
+// sellers end up in the large rooms
+func roomWeightExponent(capacity, largest int) float64 {
+	if largest <= 0 {
+		return 1
+	}
+	return 2 * float64(capacity) / float64(largest)
+}
+
+// Pow raises every weight to the exponent
+func (w MovieWeights) Pow(exponent float64) MovieWeights {
+	if w == nil {
+		return w
+	}
+
+	weights := MovieWeights{}
+	for movieID, weight := range w {
+		weights[movieID] = math.Pow(weight, exponent)
+	}
+	return weights
+}
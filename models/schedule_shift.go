@@ -0,0 +1,109 @@
+package models
+
+import (
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TimeSlotShiftOverflow decides what happens to time slots that a shift pushes
+// past the closing time of the room
+type TimeSlotShiftOverflow string
+
+const (
+	// Overflowing time slots are cancelled
+	DropOverflow TimeSlotShiftOverflow = "DROP"
+	// Overflowing time slots are moved anyway and reported
+	FlagOverflow TimeSlotShiftOverflow = "FLAG"
+)
+
+const ShiftedPastClosingReason = "Shifted past closing time"
+
+type TimeSlotShiftResult struct {
+	// Time slots that were moved and still end before closing time
+	Moved []TimeSlot
+	// Time slots that were cancelled because they would end after closing time
+	Dropped []TimeSlot
+	// Time slots that were moved but now end after closing time
+	Flagged []TimeSlot
+}
+
+// ShiftTimeSlots pushes back every scheduled time slot of the room that starts
+// at or after the instant and before closing time of that day by the given
+// duration. All of them move together, so the breaks between them are kept.
+func (r *Room) ShiftTimeSlots(tx *gorm.DB, after time.Time, shift time.Duration, overflow TimeSlotShiftOverflow, force bool, now time.Time) (TimeSlotShiftResult, error) {
+	result := TimeSlotShiftResult{
+		Moved:   []TimeSlot{},
+		Dropped: []TimeSlot{},
+		Flagged: []TimeSlot{},
+	}
+
+	if after.Before(now) {
+		after = now
+	}
+	_, closingTime := r.GetTimes(after)
+
+	// Time slots are moved starting with the last one, so that none of them is
+	// moved onto a time slot that has not been moved yet
+	var timeSlots []TimeSlot
+	if err := tx.Where("room_id = ? AND status = ? AND start_time >= ? AND start_time < ?", r.ID, ScheduledTimeSlot, after, closingTime).Order("start_time DESC").Find(&timeSlots).Error; err != nil {
+		return result, err
+	}
+
+	if overflow == DropOverflow && !force && len(timeSlots) > 0 {
+		dropped := []uuid.UUID{}
+		for _, timeSlot := range timeSlots {
+			if timeSlot.EndTime.Add(shift).After(closingTime) {
+				dropped = append(dropped, timeSlot.ID)
+			}
+		}
+
+		reserved, err := GetReservedTimeSlots(tx, r.ID, now, reservedTimeSlotIDsScope(dropped))
+		if err != nil {
+			return result, err
+		}
+
+		err = reservedTimeSlotsConflict(reserved, "Shifting the time slots drops reserved time slots")
+		if err != nil {
+			return result, err
+		}
+	}
+
+	for _, timeSlot := range timeSlots {
+		startTime := timeSlot.StartTime.Add(shift)
+		endTime := timeSlot.EndTime.Add(shift)
+
+		if endTime.After(closingTime) && overflow == DropOverflow {
+			slog.Debug("Dropping time slot shifted past closing time", "timeslot", timeSlot.ID, "startTime", startTime)
+			err := timeSlot.Cancel(tx, ShiftedPastClosingReason, now)
+			if err != nil {
+				return result, err
+			}
+			result.Dropped = append(result.Dropped, timeSlot)
+			continue
+		}
+
+		timeSlot.StartTime = startTime
+		timeSlot.EndTime = endTime
+
+		err := timeSlot.Save(tx)
+		if err != nil {
+			return result, err
+		}
+
+		if endTime.After(closingTime) {
+			result.Flagged = append(result.Flagged, timeSlot)
+		} else {
+			result.Moved = append(result.Moved, timeSlot)
+		}
+	}
+
+	slices.Reverse(result.Moved)
+	slices.Reverse(result.Dropped)
+	slices.Reverse(result.Flagged)
+
+	return result, nil
+}
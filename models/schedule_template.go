@@ -0,0 +1,132 @@
+package models
+
+import (
+	"time"
+
+	"github.com/PRPO-skupina-02/common/request"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ScheduleTemplate struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// ISO weekday, Monday = 1 ... Sunday = 7
+	Weekday     int
+	StartHour   int
+	StartMinute int
+
+	RoomID  uuid.UUID
+	Room    Room `gorm:"foreignKey:RoomID" json:"-"`
+	MovieID *uuid.UUID
+	Movie   *Movie `gorm:"foreignKey:MovieID" json:"-"`
+}
+
+func (st *ScheduleTemplate) Create(tx *gorm.DB) error {
+	if err := tx.Create(st).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (st *ScheduleTemplate) Save(tx *gorm.DB) error {
+	if err := tx.Save(st).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetRoomScheduleTemplates(tx *gorm.DB, roomID uuid.UUID, pagination *request.PaginationOptions, sort *request.SortOptions) ([]ScheduleTemplate, int, error) {
+	var templates []ScheduleTemplate
+
+	query := tx.Model(&ScheduleTemplate{}).Where("schedule_templates.room_id = ?", roomID).Session(&gorm.Session{})
+
+	if err := query.Scopes(request.PaginateScope(pagination), request.SortScope(sort)).Order("weekday, start_hour, start_minute").Find(&templates).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return templates, int(total), nil
+}
+
+func GetScheduleTemplate(tx *gorm.DB, roomID, templateID uuid.UUID) (ScheduleTemplate, error) {
+	template := ScheduleTemplate{
+		ID:     templateID,
+		RoomID: roomID,
+	}
+
+	if err := tx.Where(&template).First(&template).Error; err != nil {
+		return template, err
+	}
+
+	return template, nil
+}
+
+func DeleteScheduleTemplate(tx *gorm.DB, roomID, templateID uuid.UUID) error {
+	template := ScheduleTemplate{
+		ID:     templateID,
+		RoomID: roomID,
+	}
+
+	if err := tx.Where(&template).First(&template).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Delete(&template).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// isoWeekday returns the weekday of the day in UTC, the same way StartTimeOn
+// places the day, with Monday as 1 and Sunday as 7
+func isoWeekday(day time.Time) int {
+	weekday := int(day.UTC().Weekday())
+	if weekday == 0 {
+		return 7
+	}
+	return weekday
+}
+
+func (st *ScheduleTemplate) AppliesTo(day time.Time) bool {
+	return isoWeekday(day) == st.Weekday
+}
+
+func (st *ScheduleTemplate) StartTimeOn(day time.Time) time.Time {
+	return day.Truncate(durationDay).Add(time.Hour*time.Duration(st.StartHour) + time.Minute*time.Duration(st.StartMinute))
+}
+
+// selectMovie picks the pinned movie of the template, or a weighed random
+// active movie that finishes before the limit when the template does not pin one
+func (st *ScheduleTemplate) selectMovie(movies []Movie, weights MovieWeights, startTime, limit time.Time) (Movie, bool) {
+	if st.MovieID != nil {
+		for _, movie := range movies {
+			if movie.ID == *st.MovieID && movie.IsScreenable(startTime) {
+				return movie, true
+			}
+		}
+		return Movie{}, false
+	}
+
+	possibleMovies := []Movie{}
+	for _, movie := range movies {
+		if !movie.IsScreenable(startTime) {
+			continue
+		}
+		if !movie.CalculateEndTime(startTime).After(limit) {
+			possibleMovies = append(possibleMovies, movie)
+		}
+	}
+
+	if len(possibleMovies) == 0 {
+		return Movie{}, false
+	}
+
+	return WeighedSelectMovie(possibleMovies, weights), true
+}
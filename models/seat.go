@@ -0,0 +1,197 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SeatType string
+
+const (
+	StandardSeat   SeatType = "STANDARD"
+	VIPSeat        SeatType = "VIP"
+	WheelchairSeat SeatType = "WHEELCHAIR"
+	// Positions of the grid without a seat, such as aisles and stairs
+	AisleSeat SeatType = "AISLE"
+)
+
+// Seats are created in batches, so that large rooms stay below the parameter
+// limit of a single statement
+const seatBatchSize = 1000
+
+// Seat is a single position of the Rows x Columns grid of a room, rows and
+// columns are numbered from 1
+type Seat struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Row      int
+	Column   int
+	RowLabel string
+	Type     SeatType
+	// Disabled seats are kept in the layout but can not be sold
+	Disabled bool
+
+	RoomID uuid.UUID
+	Room   Room `gorm:"foreignKey:RoomID" json:"-"`
+}
+
+func (s *Seat) Save(tx *gorm.DB) error {
+	if err := tx.Save(s).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// Usable seats count towards the capacity of the room
+func (s *Seat) Usable() bool {
+	return s.Type != AisleSeat && !s.Disabled
+}
+
+// DefaultRowLabel labels rows like spreadsheet columns: A to Z, then AA, AB, ...
+func DefaultRowLabel(row int) string {
+	label := ""
+	for row > 0 {
+		row--
+		label = string(rune('A'+row%26)) + label
+		row /= 26
+	}
+	return label
+}
+
+// GetRoomSeats returns the seats inside of the grid of the room. Seats that
+// were kept outside of it for their reservation history are left out.
+func GetRoomSeats(tx *gorm.DB, roomID uuid.UUID) ([]Seat, error) {
+	var seats []Seat
+
+	query := tx.Joins("JOIN rooms ON rooms.id = seats.room_id").
+		Where(`seats.room_id = ? AND seats."row" <= rooms.rows AND seats."column" <= rooms.columns`, roomID)
+
+	if err := query.Order(`seats."row"`).Order(`seats."column"`).Find(&seats).Error; err != nil {
+		return nil, err
+	}
+
+	return seats, nil
+}
+
+// RoomLayoutChangedReason is given to time slots that are cancelled because
+// their reserved seats were removed from the room
+const RoomLayoutChangedReason = "Room layout was changed"
+
+// PrepareResize keeps shrinking the room to rows and columns from silently
+// dropping reservations. Future time slots with reserved seats outside of the
+// new grid are reported in a conflict error, or cancelled when forced.
+func (r *Room) PrepareResize(tx *gorm.DB, rows, columns int, force bool, now time.Time) error {
+	if rows >= r.Rows && columns >= r.Columns {
+		return nil
+	}
+
+	reserved, err := GetReservedTimeSlots(tx, r.ID, now, func(db *gorm.DB) *gorm.DB {
+		return db.Joins("JOIN seats ON seats.id = seat_reservations.seat_id").Where(`seats."row" > ? OR seats."column" > ?`, rows, columns)
+	})
+	if err != nil {
+		return err
+	}
+
+	return resolveReservedTimeSlots(tx, reserved, "Resizing the room removes reserved seats", RoomLayoutChangedReason, force, now)
+}
+
+// PrepareSeatChanges keeps changing the type of seats or disabling them from
+// silently invalidating reservations. Future time slots with reservations for
+// the seats are reported in a conflict error, or cancelled when forced.
+func (r *Room) PrepareSeatChanges(tx *gorm.DB, seatIDs []uuid.UUID, force bool, now time.Time) error {
+	if len(seatIDs) == 0 {
+		return nil
+	}
+
+	reserved, err := GetReservedTimeSlots(tx, r.ID, now, func(db *gorm.DB) *gorm.DB {
+		return db.Where("seat_reservations.seat_id IN ?", seatIDs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return resolveReservedTimeSlots(tx, reserved, "Changing the seats affects reserved seats", RoomLayoutChangedReason, force, now)
+}
+
+// ResizeSeats makes the seats of the room match its Rows and Columns. Seats
+// outside of the grid are removed, while missing ones are created as standard
+// seats labelled like the rest of their row. Seats with confirmed
+// reservations are disabled and kept instead, so that the reservations stay
+// in the history.
+func (r *Room) ResizeSeats(tx *gorm.DB) error {
+	outside := func(db *gorm.DB) *gorm.DB {
+		return db.Where(`room_id = ? AND ("row" > ? OR "column" > ?)`, r.ID, r.Rows, r.Columns)
+	}
+	confirmed := tx.Model(&SeatReservation{}).Select("seat_id").Where("status = ?", ConfirmedSeatReservation)
+
+	if err := tx.Model(&Seat{}).Scopes(outside).Where("id IN (?)", confirmed).UpdateColumn("disabled", true).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Scopes(outside).Where("id NOT IN (?)", confirmed).Delete(&Seat{}).Error; err != nil {
+		return err
+	}
+
+	seats, err := GetRoomSeats(tx, r.ID)
+	if err != nil {
+		return err
+	}
+
+	type position struct{ row, column int }
+	existing := map[position]bool{}
+	labels := map[int]string{}
+	for _, seat := range seats {
+		existing[position{seat.Row, seat.Column}] = true
+		labels[seat.Row] = seat.RowLabel
+	}
+
+	created := []Seat{}
+	for row := 1; row <= r.Rows; row++ {
+		label, ok := labels[row]
+		if !ok {
+			label = DefaultRowLabel(row)
+		}
+
+		for column := 1; column <= r.Columns; column++ {
+			if existing[position{row, column}] {
+				continue
+			}
+
+			created = append(created, Seat{
+				ID:       uuid.New(),
+				Row:      row,
+				Column:   column,
+				RowLabel: label,
+				Type:     StandardSeat,
+				RoomID:   r.ID,
+			})
+		}
+	}
+
+	if len(created) > 0 {
+		if err := tx.CreateInBatches(created, seatBatchSize).Error; err != nil {
+			return err
+		}
+	}
+
+	return r.UpdateCapacity(tx)
+}
+
+// UpdateCapacity stores the number of usable seats of the room
+func (r *Room) UpdateCapacity(tx *gorm.DB) error {
+	var capacity int64
+	if err := tx.Model(&Seat{}).Where("room_id = ? AND type <> ? AND NOT disabled", r.ID, AisleSeat).Count(&capacity).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(r).UpdateColumn("capacity", capacity).Error; err != nil {
+		return err
+	}
+
+	r.Capacity = int(capacity)
+	return nil
+}
@@ -0,0 +1,193 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Rooms can not be larger than this, matching the bounds of room requests
+const (
+	MaxRoomRows    = 100
+	MaxRoomColumns = 100
+)
+
+// Characters of a text seat plan, spaces are gaps as well
+const (
+	SeatPlanStandard   = 'S'
+	SeatPlanVIP        = 'V'
+	SeatPlanWheelchair = 'W'
+	SeatPlanGap        = '.'
+	// Disabled seats are only marked in rendered seat plans
+	SeatPlanDisabled = 'X'
+)
+
+var seatPlanTypes = map[rune]SeatType{
+	SeatPlanStandard:   StandardSeat,
+	SeatPlanVIP:        VIPSeat,
+	SeatPlanWheelchair: WheelchairSeat,
+	SeatPlanGap:        AisleSeat,
+	' ':                AisleSeat,
+}
+
+var seatPlanCharacters = map[SeatType]rune{
+	StandardSeat:   SeatPlanStandard,
+	VIPSeat:        SeatPlanVIP,
+	WheelchairSeat: SeatPlanWheelchair,
+	AisleSeat:      SeatPlanGap,
+}
+
+// SeatPlan is a parsed text seat plan, with a seat type for every position
+type SeatPlan struct {
+	Rows    int
+	Columns int
+	Types   [][]SeatType
+}
+
+// ParseSeatPlan parses a plan with one line per row and one character per
+// seat. Blank lines before and after the plan are ignored, while shorter rows
+// are filled up with gaps.
+func ParseSeatPlan(text string) (SeatPlan, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	plan := SeatPlan{
+		Rows:  len(lines),
+		Types: [][]SeatType{},
+	}
+
+	if plan.Rows == 0 {
+		return plan, middleware.NewBadRequestError("Seat plan is empty")
+	}
+	if plan.Rows > MaxRoomRows {
+		return plan, middleware.NewBadRequestError(fmt.Sprintf("Seat plan must not have more than %d rows", MaxRoomRows))
+	}
+
+	for row, line := range lines {
+		characters := []rune(line)
+		if len(characters) > MaxRoomColumns {
+			return plan, middleware.NewBadRequestError(fmt.Sprintf("Row %d of the seat plan must not have more than %d columns", row+1, MaxRoomColumns))
+		}
+
+		types := []SeatType{}
+		for column, character := range characters {
+			seatType, ok := seatPlanTypes[character]
+			if !ok {
+				return plan, middleware.NewBadRequestError(fmt.Sprintf("Unknown character %q at row %d, column %d of the seat plan", character, row+1, column+1))
+			}
+			types = append(types, seatType)
+		}
+
+		plan.Types = append(plan.Types, types)
+		plan.Columns = max(plan.Columns, len(types))
+	}
+
+	for row := range plan.Types {
+		for len(plan.Types[row]) < plan.Columns {
+			plan.Types[row] = append(plan.Types[row], AisleSeat)
+		}
+	}
+
+	return plan, nil
+}
+
+// ApplySeatPlan resizes the room to the plan and changes the types of its
+// seats, while row labels and disabled seats are kept. Unless forced, plans
+// that remove or change reserved seats are refused with a conflict error.
+func (r *Room) ApplySeatPlan(tx *gorm.DB, plan SeatPlan, force bool, now time.Time) error {
+	if err := r.PrepareResize(tx, plan.Rows, plan.Columns, force, now); err != nil {
+		return err
+	}
+
+	seats, err := GetRoomSeats(tx, r.ID)
+	if err != nil {
+		return err
+	}
+
+	changed := []uuid.UUID{}
+	for _, seat := range seats {
+		if seat.Row > plan.Rows || seat.Column > plan.Columns {
+			continue
+		}
+		if seat.Type != plan.Types[seat.Row-1][seat.Column-1] {
+			changed = append(changed, seat.ID)
+		}
+	}
+
+	if err := r.PrepareSeatChanges(tx, changed, force, now); err != nil {
+		return err
+	}
+
+	r.Rows = plan.Rows
+	r.Columns = plan.Columns
+
+	if err := r.Save(tx); err != nil {
+		return err
+	}
+
+	if err := r.ResizeSeats(tx); err != nil {
+		return err
+	}
+
+	seats, err = GetRoomSeats(tx, r.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, seat := range seats {
+		seatType := plan.Types[seat.Row-1][seat.Column-1]
+		if seat.Type == seatType {
+			continue
+		}
+
+		seat.Type = seatType
+		if err := seat.Save(tx); err != nil {
+			return err
+		}
+	}
+
+	return r.UpdateCapacity(tx)
+}
+
+// RenderSeatPlan draws seats ordered by row and column in the text seat plan
+// format, with every line prefixed by the row label
+func RenderSeatPlan(seats []Seat) []string {
+	width := 0
+	for _, seat := range seats {
+		width = max(width, len(seat.RowLabel))
+	}
+
+	lines := []string{}
+	var line strings.Builder
+	for i, seat := range seats {
+		if i == 0 || seats[i-1].Row != seat.Row {
+			fmt.Fprintf(&line, "%-*s ", width, seat.RowLabel)
+		}
+
+		character := seatPlanCharacters[seat.Type]
+		if seat.Disabled && seat.Type != AisleSeat {
+			character = SeatPlanDisabled
+		}
+		line.WriteRune(character)
+
+		if i == len(seats)-1 || seats[i+1].Row != seat.Row {
+			lines = append(lines, line.String())
+			line.Reset()
+		}
+	}
+
+	return lines
+}
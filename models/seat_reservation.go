@@ -0,0 +1,343 @@
+package models
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PRPO-skupina-02/common/middleware"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SeatReservationStatus string
+
+const (
+	HeldSeatReservation      SeatReservationStatus = "HELD"
+	ConfirmedSeatReservation SeatReservationStatus = "CONFIRMED"
+)
+
+// SeatReservationConstraint keeps a seat from being held or reserved twice for
+// the same time slot
+const SeatReservationConstraint = "seat_reservations_time_slot_id_seat_id_key"
+
+const DefaultSeatHoldTTL = 10 * time.Minute
+
+// SeatReservation holds or reserves a single seat for a time slot. Seats held
+// together share a hold, which is released or confirmed as a whole.
+type SeatReservation struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	HoldID uuid.UUID
+	// User who held the seats, holds made before owners were recorded have none
+	UserID *uuid.UUID
+	Status SeatReservationStatus
+	// Held seats that are not confirmed by then are released again
+	ExpiresAt *time.Time
+
+	TimeSlotID uuid.UUID
+	TimeSlot   TimeSlot `gorm:"foreignKey:TimeSlotID" json:"-"`
+	SeatID     uuid.UUID
+	Seat       Seat `gorm:"foreignKey:SeatID" json:"-"`
+}
+
+func (sr *SeatReservation) Expired(now time.Time) bool {
+	return sr.Status == HeldSeatReservation && sr.ExpiresAt != nil && !sr.ExpiresAt.After(now)
+}
+
+// activeSeatReservationsScope limits reservations to confirmed ones and holds
+// that have not expired yet
+func activeSeatReservationsScope(now time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("seat_reservations.status = ? OR seat_reservations.expires_at > ?", ConfirmedSeatReservation, now)
+	}
+}
+
+// SeatHoldOwnerScope limits reservations to the holds of the user
+func SeatHoldOwnerScope(userID uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("seat_reservations.user_id = ?", userID)
+	}
+}
+
+func orderedBySeatScope(db *gorm.DB) *gorm.DB {
+	return db.Joins("JOIN seats ON seats.id = seat_reservations.seat_id").Order(`seats."row"`).Order(`seats."column"`)
+}
+
+// GetTimeSlotSeatReservations returns the confirmed reservations and the holds
+// of the time slot that have not expired yet
+func GetTimeSlotSeatReservations(tx *gorm.DB, timeSlotID uuid.UUID, now time.Time) ([]SeatReservation, error) {
+	var reservations []SeatReservation
+
+	if err := tx.Where("seat_reservations.time_slot_id = ?", timeSlotID).Scopes(activeSeatReservationsScope(now), orderedBySeatScope).Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// CountActiveSeatReservations counts the confirmed reservations and the holds
+// of the time slot that have not expired yet
+func CountActiveSeatReservations(tx *gorm.DB, timeSlotID uuid.UUID, now time.Time) (int, error) {
+	var count int64
+
+	if err := tx.Model(&SeatReservation{}).Where("seat_reservations.time_slot_id = ?", timeSlotID).Scopes(activeSeatReservationsScope(now)).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// HoldSeats holds the seats for the time slot until the hold expires. Seats
+// that are already held or reserved are reported in the fields of a conflict
+// error, the unique constraint guards against concurrent holds.
+func (ts *TimeSlot) HoldSeats(tx *gorm.DB, seats []Seat, userID uuid.UUID, ttl time.Duration, now time.Time) ([]SeatReservation, error) {
+	if err := tx.Where("time_slot_id = ? AND status = ? AND expires_at <= ?", ts.ID, HeldSeatReservation, now).Delete(&SeatReservation{}).Error; err != nil {
+		return nil, err
+	}
+
+	seatIDs := []uuid.UUID{}
+	for _, seat := range seats {
+		seatIDs = append(seatIDs, seat.ID)
+	}
+
+	var taken []SeatReservation
+	if err := tx.Where("time_slot_id = ? AND seat_id IN ?", ts.ID, seatIDs).Find(&taken).Error; err != nil {
+		return nil, err
+	}
+
+	if len(taken) > 0 {
+		fields := map[string]string{}
+		for _, reservation := range taken {
+			fields[reservation.SeatID.String()] = string(reservation.Status)
+		}
+
+		return nil, &middleware.HttpError{
+			Code:    http.StatusConflict,
+			Message: "Seats are not available",
+			Fields:  fields,
+		}
+	}
+
+	holdID := uuid.New()
+	expiresAt := now.Add(ttl)
+
+	reservations := []SeatReservation{}
+	for _, seat := range seats {
+		reservations = append(reservations, SeatReservation{
+			ID:         uuid.New(),
+			HoldID:     holdID,
+			UserID:     &userID,
+			Status:     HeldSeatReservation,
+			ExpiresAt:  &expiresAt,
+			TimeSlotID: ts.ID,
+			SeatID:     seat.ID,
+		})
+	}
+
+	if err := tx.Create(&reservations).Error; err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// GetSeatHold returns the reservations of the hold ordered by seat. Scopes
+// limit the holds that can be found.
+func GetSeatHold(tx *gorm.DB, timeSlotID, holdID uuid.UUID, scopes ...func(*gorm.DB) *gorm.DB) ([]SeatReservation, error) {
+	var reservations []SeatReservation
+
+	if err := tx.Where("seat_reservations.time_slot_id = ? AND seat_reservations.hold_id = ?", timeSlotID, holdID).Scopes(scopes...).Scopes(orderedBySeatScope).Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+
+	if len(reservations) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return reservations, nil
+}
+
+// ConfirmSeatHold turns the held seats into reservations that do not expire
+func ConfirmSeatHold(tx *gorm.DB, timeSlotID, holdID uuid.UUID, now time.Time) ([]SeatReservation, error) {
+	reservations, err := GetSeatHold(tx, timeSlotID, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reservation := range reservations {
+		if reservation.Expired(now) {
+			return nil, &middleware.HttpError{
+				Code:    http.StatusConflict,
+				Message: "Seat hold has expired",
+			}
+		}
+	}
+
+	for i := range reservations {
+		reservations[i].Status = ConfirmedSeatReservation
+		reservations[i].ExpiresAt = nil
+
+		if err := tx.Save(&reservations[i]).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return reservations, nil
+}
+
+// ReleaseSeatHold frees the held seats, confirmed reservations can not be
+// released. Scopes limit the holds that can be released.
+func ReleaseSeatHold(tx *gorm.DB, timeSlotID, holdID uuid.UUID, scopes ...func(*gorm.DB) *gorm.DB) error {
+	reservations, err := GetSeatHold(tx, timeSlotID, holdID, scopes...)
+	if err != nil {
+		return err
+	}
+
+	for _, reservation := range reservations {
+		if reservation.Status == ConfirmedSeatReservation {
+			return middleware.NewBadRequestError("Confirmed seats can not be released")
+		}
+	}
+
+	if err := tx.Where("time_slot_id = ? AND hold_id = ?", timeSlotID, holdID).Delete(&SeatReservation{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// CancelSeatReservation frees the seats of a confirmed hold, holds that are
+// not confirmed yet can only be released
+func CancelSeatReservation(tx *gorm.DB, timeSlotID, holdID uuid.UUID) error {
+	reservations, err := GetSeatHold(tx, timeSlotID, holdID)
+	if err != nil {
+		return err
+	}
+
+	for _, reservation := range reservations {
+		if reservation.Status != ConfirmedSeatReservation {
+			return middleware.NewBadRequestError("Only confirmed reservations can be cancelled")
+		}
+	}
+
+	if err := tx.Where("time_slot_id = ? AND hold_id = ?", timeSlotID, holdID).Delete(&SeatReservation{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReleaseExpiredSeatHolds frees the seats of every hold that has expired and
+// returns the number of released seats
+func ReleaseExpiredSeatHolds(tx *gorm.DB, now time.Time) (int64, error) {
+	result := tx.Where("status = ? AND expires_at <= ?", HeldSeatReservation, now).Delete(&SeatReservation{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// ReservedTimeSlot is a time slot together with its number of active
+// reservations
+type ReservedTimeSlot struct {
+	TimeSlot     TimeSlot
+	Reservations int
+}
+
+// GetReservedTimeSlots returns the scheduled time slots of the room starting
+// after now that have confirmed reservations or holds that have not expired
+// yet, ordered by start time. Scopes limit the counted reservations.
+func GetReservedTimeSlots(tx *gorm.DB, roomID uuid.UUID, now time.Time, scopes ...func(*gorm.DB) *gorm.DB) ([]ReservedTimeSlot, error) {
+	return getReservedTimeSlots(tx, now, append([]func(*gorm.DB) *gorm.DB{func(db *gorm.DB) *gorm.DB {
+		return db.Where("time_slots.room_id = ?", roomID)
+	}}, scopes...)...)
+}
+
+// reservedTimeSlotIDsScope limits reservations to the given time slots
+func reservedTimeSlotIDsScope(timeSlotIDs []uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("seat_reservations.time_slot_id IN ?", timeSlotIDs)
+	}
+}
+
+func getReservedTimeSlots(tx *gorm.DB, now time.Time, scopes ...func(*gorm.DB) *gorm.DB) ([]ReservedTimeSlot, error) {
+	var counts []struct {
+		TimeSlotID   uuid.UUID
+		Reservations int
+	}
+
+	query := tx.Model(&SeatReservation{}).
+		Select("seat_reservations.time_slot_id, COUNT(*) AS reservations").
+		Joins("JOIN time_slots ON time_slots.id = seat_reservations.time_slot_id").
+		Where("time_slots.status = ? AND time_slots.start_time > ?", ScheduledTimeSlot, now).
+		Scopes(activeSeatReservationsScope(now)).
+		Scopes(scopes...).
+		Group("seat_reservations.time_slot_id")
+
+	if err := query.Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	if len(counts) == 0 {
+		return []ReservedTimeSlot{}, nil
+	}
+
+	reservations := map[uuid.UUID]int{}
+	timeSlotIDs := []uuid.UUID{}
+	for _, count := range counts {
+		reservations[count.TimeSlotID] = count.Reservations
+		timeSlotIDs = append(timeSlotIDs, count.TimeSlotID)
+	}
+
+	var timeSlots []TimeSlot
+	if err := tx.Where("time_slots.id IN ?", timeSlotIDs).Preload("Movie").Order("time_slots.start_time").Find(&timeSlots).Error; err != nil {
+		return nil, err
+	}
+
+	reserved := []ReservedTimeSlot{}
+	for _, timeSlot := range timeSlots {
+		reserved = append(reserved, ReservedTimeSlot{
+			TimeSlot:     timeSlot,
+			Reservations: reservations[timeSlot.ID],
+		})
+	}
+
+	return reserved, nil
+}
+
+// reservedTimeSlotsConflict is a conflict error listing every reserved time
+// slot, or nil when there are none
+func reservedTimeSlotsConflict(reserved []ReservedTimeSlot, message string) error {
+	if len(reserved) == 0 {
+		return nil
+	}
+
+	fields := map[string]string{}
+	for _, r := range reserved {
+		fields[r.TimeSlot.ID.String()] = fmt.Sprintf("%s at %s, reserved seats: %d", r.TimeSlot.Movie.Title, r.TimeSlot.StartTime.UTC().Format(time.RFC3339), r.Reservations)
+	}
+
+	return &middleware.HttpError{
+		Code:    http.StatusConflict,
+		Message: message,
+		Fields:  fields,
+	}
+}
+
+// resolveReservedTimeSlots cancels the reserved time slots when forced and
+// otherwise refuses with a conflict error listing every one of them
+func resolveReservedTimeSlots(tx *gorm.DB, reserved []ReservedTimeSlot, message, reason string, force bool, now time.Time) error {
+	if !force {
+		return reservedTimeSlotsConflict(reserved, message)
+	}
+
+	for _, r := range reserved {
+		err := r.TimeSlot.Cancel(tx, reason, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -15,6 +15,11 @@ type Theater struct {
 
 	Name string
 
+	// Number of days to keep scheduled ahead, nil uses the global default
+	ScheduleHorizonDays *int
+
+	DeletedAt gorm.DeletedAt
+
 	Rooms []Room `gorm:"foreignKey:TheaterID" json:"-"`
 }
 
@@ -32,12 +37,12 @@ func (t *Theater) Save(tx *gorm.DB) error {
 	return nil
 }
 
-func GetTheaters(tx *gorm.DB, pagination *request.PaginationOptions, sort *request.SortOptions) ([]Theater, int, error) {
+func GetTheaters(tx *gorm.DB, pagination *request.PaginationOptions, sort *request.SortOptions, scopes ...func(*gorm.DB) *gorm.DB) ([]Theater, int, error) {
 	var theaters []Theater
 
 	query := tx.Model(&Theater{}).Session(&gorm.Session{})
 
-	if err := query.Scopes(request.PaginateScope(pagination), request.SortScope(sort)).Find(&theaters).Error; err != nil {
+	if err := query.Scopes(request.PaginateScope(pagination), request.SortScope(sort)).Scopes(scopes...).Find(&theaters).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -49,19 +54,22 @@ func GetTheaters(tx *gorm.DB, pagination *request.PaginationOptions, sort *reque
 	return theaters, int(total), nil
 }
 
-func GetTheater(tx *gorm.DB, id uuid.UUID) (Theater, error) {
+func GetTheater(tx *gorm.DB, id uuid.UUID, scopes ...func(*gorm.DB) *gorm.DB) (Theater, error) {
 	theater := Theater{
 		ID: id,
 	}
 
-	if err := tx.Where(&theater).First(&theater).Error; err != nil {
+	if err := tx.Where(&theater).Scopes(scopes...).First(&theater).Error; err != nil {
 		return theater, err
 	}
 
 	return theater, nil
 }
 
-func DeleteTheater(tx *gorm.DB, id uuid.UUID) error {
+// DeleteTheater deletes the theater together with its rooms. Unless forced,
+// theaters with reservations for future time slots are not deleted and the
+// reserved time slots of every room are reported in a conflict error instead.
+func DeleteTheater(tx *gorm.DB, id uuid.UUID, force bool) error {
 	theater := Theater{
 		ID: id,
 	}
@@ -70,8 +78,25 @@ func DeleteTheater(tx *gorm.DB, id uuid.UUID) error {
 		return err
 	}
 
+	if !force {
+		now := time.Now()
+		reserved := []ReservedTimeSlot{}
+		for _, room := range theater.Rooms {
+			roomReserved, err := GetReservedTimeSlots(tx, room.ID, now)
+			if err != nil {
+				return err
+			}
+			reserved = append(reserved, roomReserved...)
+		}
+
+		err := reservedTimeSlotsConflict(reserved, "Theater has reservations for future time slots")
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, room := range theater.Rooms {
-		err := DeleteRoom(tx, id, room.ID)
+		err := deleteRoom(tx, id, room.ID, TheaterDeletedReason, true)
 		if err != nil {
 			return err
 		}
@@ -83,14 +108,46 @@ func DeleteTheater(tx *gorm.DB, id uuid.UUID) error {
 	return nil
 }
 
-func (t *Theater) PopulateTheater(tx *gorm.DB, now time.Time, days int, movies []Movie) error {
-	rooms, _, err := GetTheaterRooms(tx, t.ID, nil, nil)
+// PreloadOrderedRoomsScope preloads the rooms of theaters in order of creation
+func PreloadOrderedRoomsScope(db *gorm.DB) *gorm.DB {
+	return db.Preload("Rooms", func(db *gorm.DB) *gorm.DB {
+		return db.Order("rooms.created_at, rooms.id")
+	})
+}
+
+// largestCapacity returns the capacity of the largest of the rooms
+func largestCapacity(rooms []Room) int {
+	largest := 0
+	for _, room := range rooms {
+		largest = max(largest, room.Capacity)
+	}
+	return largest
+}
+
+func (t *Theater) PopulateTheater(tx *gorm.DB, now time.Time, days int, movies []Movie, run *ScheduleRun) error {
+	rooms, _, err := GetTheaterRooms(tx, t.ID, nil, nil, PreloadOrderedTimeSlotsScope)
+	if err != nil {
+		return err
+	}
+
+	for _, room := range rooms {
+		err := room.PopulateRoom(tx, now, days, movies, run.RoomWeights(room.ID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *Theater) ExtendTheater(tx *gorm.DB, now time.Time, days int, movies []Movie, run *ScheduleRun) error {
+	rooms, _, err := GetTheaterRooms(tx, t.ID, nil, nil, PreloadOrderedTimeSlotsScope)
 	if err != nil {
 		return err
 	}
 
 	for _, room := range rooms {
-		err := room.PopulateRoom(tx, now, days, movies)
+		err := room.ExtendRoom(tx, now, days, movies, run.RoomWeights(room.ID))
 		if err != nil {
 			return err
 		}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/PRPO-skupina-02/common/request"
@@ -8,6 +9,18 @@ import (
 	"gorm.io/gorm"
 )
 
+type TimeSlotStatus string
+
+const (
+	ScheduledTimeSlot TimeSlotStatus = "SCHEDULED"
+	CancelledTimeSlot TimeSlotStatus = "CANCELLED"
+	CompletedTimeSlot TimeSlotStatus = "COMPLETED"
+)
+
+// TimeSlotOverlapConstraint keeps time slots of a room that are not cancelled
+// from overlapping
+const TimeSlotOverlapConstraint = "time_slots_room_id_period_excl"
+
 type TimeSlot struct {
 	ID        uuid.UUID
 	CreatedAt time.Time
@@ -15,6 +28,12 @@ type TimeSlot struct {
 
 	StartTime time.Time
 	EndTime   time.Time
+	// Locked time slots are never moved or cancelled by automatic rescheduling
+	Locked bool
+
+	Status             TimeSlotStatus
+	CancellationReason *string
+	CancelledAt        *time.Time
 
 	RoomID  uuid.UUID
 	Room    Room `gorm:"foreignKey:RoomID" json:"-"`
@@ -22,6 +41,14 @@ type TimeSlot struct {
 	Movie   Movie `gorm:"foreignKey:MovieID" json:"-"`
 }
 
+func (ts *TimeSlot) BeforeCreate(tx *gorm.DB) error {
+	if ts.Status == "" {
+		ts.Status = ScheduledTimeSlot
+	}
+
+	return nil
+}
+
 func (ts *TimeSlot) Create(tx *gorm.DB) error {
 	if err := tx.Create(ts).Error; err != nil {
 		return err
@@ -36,12 +63,127 @@ func (ts *TimeSlot) Save(tx *gorm.DB) error {
 	return nil
 }
 
-func GetRoomTimeSlots(tx *gorm.DB, roomID uuid.UUID, pagination *request.PaginationOptions, sort *request.SortOptions, filters *request.FilterOptions) ([]TimeSlot, int, error) {
+// TimeSlotStatusFilter limits time slots to the given statuses, or to the ones
+// that are not cancelled when no status is given
+type TimeSlotStatusFilter struct {
+	Statuses []TimeSlotStatus
+}
+
+func (f TimeSlotStatusFilter) Apply(db *gorm.DB) *gorm.DB {
+	if len(f.Statuses) == 0 {
+		return db.Where("time_slots.status <> ?", CancelledTimeSlot)
+	}
+	return db.Where("time_slots.status IN ?", f.Statuses)
+}
+
+// TimeRangeFilter limits the column to [From, To), either bound can be omitted
+type TimeRangeFilter struct {
+	Column string
+	From   *time.Time
+	To     *time.Time
+}
+
+func (f TimeRangeFilter) Apply(db *gorm.DB) *gorm.DB {
+	if f.From != nil {
+		db = db.Where(fmt.Sprintf("%s >= ?", f.Column), *f.From)
+	}
+	if f.To != nil {
+		db = db.Where(fmt.Sprintf("%s < ?", f.Column), *f.To)
+	}
+	return db
+}
+
+// Expressions matching the time_slots_start_time_of_day_idx and
+// time_slots_start_weekday_idx indexes, times of day and weekdays are in UTC
+const (
+	startTimeOfDayExpression = "((time_slots.start_time AT TIME ZONE 'UTC')::time)"
+	startWeekdayExpression   = "(EXTRACT(ISODOW FROM time_slots.start_time AT TIME ZONE 'UTC'))"
+)
+
+// TimeOfDayFilter limits time slots to the ones starting within [After, Before),
+// the range wraps around midnight when After is later than Before
+type TimeOfDayFilter struct {
+	After  *time.Time
+	Before *time.Time
+}
+
+func (f TimeOfDayFilter) Apply(db *gorm.DB) *gorm.DB {
+	if f.After != nil && f.Before != nil && f.After.After(*f.Before) {
+		return db.Where(fmt.Sprintf("(%[1]s >= ? OR %[1]s < ?)", startTimeOfDayExpression), f.After.Format(time.TimeOnly), f.Before.Format(time.TimeOnly))
+	}
+	if f.After != nil {
+		db = db.Where(fmt.Sprintf("%s >= ?", startTimeOfDayExpression), f.After.Format(time.TimeOnly))
+	}
+	if f.Before != nil {
+		db = db.Where(fmt.Sprintf("%s < ?", startTimeOfDayExpression), f.Before.Format(time.TimeOnly))
+	}
+	return db
+}
+
+type TimeSlotWeekdayFilter struct {
+	Weekdays []time.Weekday
+}
+
+func (f TimeSlotWeekdayFilter) Apply(db *gorm.DB) *gorm.DB {
+	isoWeekdays := []int{}
+	for _, weekday := range f.Weekdays {
+		if weekday == time.Sunday {
+			isoWeekdays = append(isoWeekdays, 7)
+		} else {
+			isoWeekdays = append(isoWeekdays, int(weekday))
+		}
+	}
+	return db.Where(fmt.Sprintf("%s IN ?", startWeekdayExpression), isoWeekdays)
+}
+
+type TimeSlotMovieFilter struct {
+	MovieID uuid.UUID
+}
+
+func (f TimeSlotMovieFilter) Apply(db *gorm.DB) *gorm.DB {
+	return db.Where("time_slots.movie_id = ?", f.MovieID)
+}
+
+type TimeSlotRoomFilter struct {
+	RoomID uuid.UUID
+}
+
+func (f TimeSlotRoomFilter) Apply(db *gorm.DB) *gorm.DB {
+	return db.Where("time_slots.room_id = ?", f.RoomID)
+}
+
+type TimeSlotTheaterFilter struct {
+	TheaterID uuid.UUID
+}
+
+func (f TimeSlotTheaterFilter) Apply(db *gorm.DB) *gorm.DB {
+	return db.Where("time_slots.room_id IN (SELECT rooms.id FROM rooms WHERE rooms.theater_id = ?)", f.TheaterID)
+}
+
+func GetRoomTimeSlots(tx *gorm.DB, roomID uuid.UUID, pagination *request.PaginationOptions, sort *request.SortOptions, filters *request.FilterOptions, scopes ...func(*gorm.DB) *gorm.DB) ([]TimeSlot, int, error) {
 	var timeSlots []TimeSlot
 
 	query := tx.Model(&TimeSlot{}).Where("time_slots.room_id = ?", roomID).Scopes(request.FilterScope(filters)).Session(&gorm.Session{})
 
-	if err := query.Scopes(request.PaginateScope(pagination), request.SortScope(sort)).Preload("Movie").Find(&timeSlots).Error; err != nil {
+	if err := query.Scopes(request.PaginateScope(pagination), request.SortScope(sort)).Scopes(scopes...).Find(&timeSlots).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return timeSlots, int(total), nil
+}
+
+func GetTheaterTimeSlots(tx *gorm.DB, theaterID uuid.UUID, pagination *request.PaginationOptions, sort *request.SortOptions, filters *request.FilterOptions, scopes ...func(*gorm.DB) *gorm.DB) ([]TimeSlot, int, error) {
+	var timeSlots []TimeSlot
+
+	rooms := tx.Model(&Room{}).Select("id").Where("rooms.theater_id = ?", theaterID)
+	query := tx.Model(&TimeSlot{}).Where("time_slots.room_id IN (?)", rooms).Scopes(request.FilterScope(filters)).Session(&gorm.Session{})
+
+	if err := query.Scopes(request.PaginateScope(pagination), request.SortScope(sort)).Order("time_slots.start_time, time_slots.id").Preload("Room").Preload("Movie").Scopes(scopes...).Find(&timeSlots).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -53,13 +195,47 @@ func GetRoomTimeSlots(tx *gorm.DB, roomID uuid.UUID, pagination *request.Paginat
 	return timeSlots, int(total), nil
 }
 
-func GetTimeSlot(tx *gorm.DB, roomID, timeSlotID uuid.UUID) (TimeSlot, error) {
+// GetMovieShowtimes returns scheduled time slots of the movie that start after
+// the given instant, in rooms that have not been deleted
+func GetMovieShowtimes(tx *gorm.DB, movieID uuid.UUID, now time.Time, filters *request.FilterOptions) ([]TimeSlot, error) {
+	var timeSlots []TimeSlot
+
+	rooms := tx.Model(&Room{}).Select("id")
+	query := tx.Model(&TimeSlot{}).
+		Where("time_slots.movie_id = ?", movieID).
+		Where("time_slots.status = ?", ScheduledTimeSlot).
+		Where("time_slots.start_time > ?", now).
+		Where("time_slots.room_id IN (?)", rooms).
+		Scopes(request.FilterScope(filters))
+
+	if err := query.Order("time_slots.start_time, time_slots.id").Preload("Room.Theater").Find(&timeSlots).Error; err != nil {
+		return nil, err
+	}
+
+	return timeSlots, nil
+}
+
+// GetCalendarTimeSlots returns time slots of every status matching the filters
+// with their movie, room and theater, which are loaded even when deleted
+func GetCalendarTimeSlots(tx *gorm.DB, filters *request.FilterOptions) ([]TimeSlot, error) {
+	var timeSlots []TimeSlot
+
+	query := tx.Model(&TimeSlot{}).Scopes(request.FilterScope(filters)).Order("time_slots.start_time, time_slots.id")
+
+	if err := query.Preload("Movie", unscopedScope).Preload("Room", unscopedScope).Preload("Room.Theater", unscopedScope).Find(&timeSlots).Error; err != nil {
+		return nil, err
+	}
+
+	return timeSlots, nil
+}
+
+func GetTimeSlot(tx *gorm.DB, roomID, timeSlotID uuid.UUID, scopes ...func(*gorm.DB) *gorm.DB) (TimeSlot, error) {
 	timeSlot := TimeSlot{
 		ID:     timeSlotID,
 		RoomID: roomID,
 	}
 
-	if err := tx.Where(&timeSlot).First(&timeSlot).Error; err != nil {
+	if err := tx.Where(&timeSlot).Scopes(scopes...).First(&timeSlot).Error; err != nil {
 		return timeSlot, err
 	}
 
@@ -82,6 +258,38 @@ func DeleteTimeSlot(tx *gorm.DB, roomID, timeSlotID uuid.UUID) error {
 	return nil
 }
 
+func (ts *TimeSlot) Cancel(tx *gorm.DB, reason string, now time.Time) error {
+	ts.Status = CancelledTimeSlot
+	ts.CancellationReason = &reason
+	ts.CancelledAt = &now
+
+	return ts.Save(tx)
+}
+
+// PrepareCancel keeps cancelling the time slot from silently dropping its
+// reservations. Unless forced, a time slot with reservations is reported in a
+// conflict error.
+func (ts *TimeSlot) PrepareCancel(tx *gorm.DB, force bool, now time.Time) error {
+	if force {
+		return nil
+	}
+
+	reserved, err := getReservedTimeSlots(tx, now, reservedTimeSlotIDsScope([]uuid.UUID{ts.ID}))
+	if err != nil {
+		return err
+	}
+
+	return reservedTimeSlotsConflict(reserved, "Time slot has reservations")
+}
+
+// CompleteTimeSlots marks every scheduled time slot that has ended as completed
+func CompleteTimeSlots(tx *gorm.DB, now time.Time) error {
+	if err := tx.Model(&TimeSlot{}).Where("status = ? AND end_time <= ?", ScheduledTimeSlot, now).UpdateColumn("status", CompletedTimeSlot).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
 func (ts *TimeSlot) CoversInstant(instant time.Time) bool {
 	if ts.StartTime.Equal(instant) {
 		return true
@@ -89,3 +297,7 @@ func (ts *TimeSlot) CoversInstant(instant time.Time) bool {
 
 	return ts.StartTime.Before(instant) && ts.EndTime.After(instant)
 }
+
+func (ts *TimeSlot) Overlaps(start, end time.Time) bool {
+	return ts.StartTime.Before(end) && ts.EndTime.After(start)
+}
@@ -2,6 +2,7 @@ package spored
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/go-co-op/gocron/v2"
 	"gorm.io/gorm"
@@ -31,6 +32,15 @@ func SetupCron(db *gorm.DB) error {
 		return err
 	}
 
+	// Release seats of expired holds
+	_, err = s.NewJob(
+		gocron.DurationJob(time.Minute),
+		gocron.NewTask(SeatHoldSweep, db),
+	)
+	if err != nil {
+		return err
+	}
+
 	s.Start()
 
 	slog.Info("Cron job started", "id", j.ID())
@@ -2,17 +2,43 @@ package spored
 
 import (
 	"log/slog"
+	"strconv"
 	"time"
 
+	"github.com/PRPO-skupina-02/common/config"
 	"github.com/PRPO-skupina-02/spored/models"
 	"gorm.io/gorm"
 )
 
+const defaultHorizonDays = 7
+
+// GlobalHorizonDays returns the number of days to keep scheduled ahead for
+// theaters without their own horizon
+func GlobalHorizonDays() int {
+	days, err := strconv.Atoi(config.GetEnvDefault("SCHEDULE_HORIZON_DAYS", ""))
+	if err != nil || days <= 0 {
+		return defaultHorizonDays
+	}
+	return days
+}
+
+func TheaterHorizonDays(theater models.Theater) int {
+	if theater.ScheduleHorizonDays != nil {
+		return *theater.ScheduleHorizonDays
+	}
+	return GlobalHorizonDays()
+}
+
 func TimeSlotRefresh(db *gorm.DB) {
 	tx := db.Begin()
 
 	err := func() error {
-		err := PopulateSpored(tx)
+		err := models.CompleteTimeSlots(tx, time.Now())
+		if err != nil {
+			return err
+		}
+
+		err = ExtendSpored(tx)
 		if err != nil {
 			return err
 		}
@@ -34,6 +60,21 @@ func TimeSlotRefresh(db *gorm.DB) {
 	}
 }
 
+// SeatHoldSweep releases the seats of holds that expired without being
+// confirmed
+func SeatHoldSweep(db *gorm.DB) {
+	released, err := models.ReleaseExpiredSeatHolds(db, time.Now())
+	if err != nil {
+		slog.Error("Failed to release expired seat holds", "err", err)
+		return
+	}
+
+	if released > 0 {
+		slog.Info("Released expired seat holds", "seats", released)
+	}
+}
+
+// PopulateSpored fills every gap in the schedule of all theaters up to their horizon
 func PopulateSpored(tx *gorm.DB) error {
 	movies, _, err := models.GetMovies(tx, nil, nil)
 	if err != nil {
@@ -46,7 +87,12 @@ func PopulateSpored(tx *gorm.DB) error {
 	}
 
 	for _, theater := range theaters {
-		err = theater.PopulateTheater(tx, time.Now(), 7, movies)
+		run, err := theater.NewScheduleRun(tx, models.PopulateScheduleRun, time.Now())
+		if err != nil {
+			return err
+		}
+
+		err = theater.PopulateTheater(tx, time.Now(), TheaterHorizonDays(theater), movies, &run)
 		if err != nil {
 			return err
 		}
@@ -55,6 +101,49 @@ func PopulateSpored(tx *gorm.DB) error {
 	return nil
 }
 
+// ExtendSpored schedules only the days that became uncovered since the last run
+func ExtendSpored(tx *gorm.DB) error {
+	movies, _, err := models.GetMovies(tx, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	theaters, _, err := models.GetTheaters(tx, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, theater := range theaters {
+		run, err := theater.NewScheduleRun(tx, models.ExtendScheduleRun, time.Now())
+		if err != nil {
+			return err
+		}
+
+		err = theater.ExtendTheater(tx, time.Now(), TheaterHorizonDays(theater), movies, &run)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PopulateTheater fills every gap in the schedule of a single theater up to its
+// horizon and records the run with the movie weights it was based on
+func PopulateTheater(tx *gorm.DB, theater models.Theater) error {
+	movies, _, err := models.GetMovies(tx, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	run, err := theater.NewScheduleRun(tx, models.PopulateScheduleRun, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return theater.PopulateTheater(tx, time.Now(), TheaterHorizonDays(theater), movies, &run)
+}
+
 func PruneSpored(tx *gorm.DB) error {
 	theaters, _, err := models.GetTheaters(tx, nil, nil)
 	if err != nil {